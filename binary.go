@@ -0,0 +1,250 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	registryLock sync.RWMutex
+
+	seriesNames = map[reflect.Type]string{}
+	seriesTypes = map[string]reflect.Type{}
+
+	formatterNames = map[uintptr]string{}
+	formatters     = map[string]ValueToStringFormatter{}
+)
+
+func init() {
+	RegisterSeries("dataframe.SeriesFloat64", &SeriesFloat64{})
+	RegisterSeries("dataframe.SeriesInt64", &SeriesInt64{})
+	RegisterSeries("dataframe.SeriesString", &SeriesString{})
+	RegisterSeries("dataframe.SeriesTime", &SeriesTime{})
+	RegisterSeries("dataframe.SeriesDuration", &SeriesDuration{})
+	RegisterSeries("dataframe.SeriesMixed", &SeriesMixed{})
+	RegisterSeries("dataframe.SeriesGeneric", &SeriesGeneric{})
+	RegisterSeries("dataframe.SeriesList", &SeriesList{})
+	RegisterSeries("dataframe.SeriesStruct", &SeriesStruct{})
+
+	RegisterValueFormatter("dataframe.BoolValueFormatter", BoolValueFormatter)
+}
+
+// RegisterSeries records a custom Series under name so that it can be encoded and decoded
+// as part of a DataFrame. s must be a pointer that implements encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler. The Series is also registered with the gob package under
+// the same name so that it can be stored in interface values. To be encoded as JSON, the
+// Series must also implement json.Marshaler and json.Unmarshaler.
+//
+// RegisterSeries should be called from an init function.
+//
+// Example:
+//
+//  func init() {
+//     dataframe.RegisterSeries("mypkg.SeriesFoo", &SeriesFoo{})
+//  }
+//
+func RegisterSeries(name string, s Series) {
+	if _, ok := s.(encoding.BinaryMarshaler); !ok {
+		panic(fmt.Sprintf("%T does not implement encoding.BinaryMarshaler", s))
+	}
+	if _, ok := s.(encoding.BinaryUnmarshaler); !ok {
+		panic(fmt.Sprintf("%T does not implement encoding.BinaryUnmarshaler", s))
+	}
+
+	t := reflect.TypeOf(s)
+	if t.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("%T must be a pointer", s))
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if n, exists := seriesNames[t]; exists && n != name {
+		panic(fmt.Sprintf("%T already registered as %s", s, n))
+	}
+	if t2, exists := seriesTypes[name]; exists && t2 != t {
+		panic(fmt.Sprintf("%s already registered for %s", name, t2))
+	}
+
+	seriesNames[t] = name
+	seriesTypes[name] = t
+
+	gob.RegisterName(name, s)
+}
+
+// RegisterValueFormatter records a ValueToStringFormatter under name so that it can be
+// preserved when a Series is encoded. Formatters that are not registered (including closures)
+// are replaced with DefaultValueFormatter when the Series is decoded.
+//
+// RegisterValueFormatter should be called from an init function.
+func RegisterValueFormatter(name string, f ValueToStringFormatter) {
+	ptr := reflect.ValueOf(f).Pointer()
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	formatterNames[ptr] = name
+	formatters[name] = f
+}
+
+// ValueFormatterName returns the name that f was registered under using RegisterValueFormatter.
+// An empty string is returned for DefaultValueFormatter and formatters that are not registered.
+//
+// It is intended to be used by custom Series that implement encoding.BinaryMarshaler.
+func ValueFormatterName(f ValueToStringFormatter) string {
+	if f == nil {
+		return ""
+	}
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return formatterNames[reflect.ValueOf(f).Pointer()]
+}
+
+// LookupValueFormatter returns the ValueToStringFormatter registered under name.
+// DefaultValueFormatter is returned if name is not registered.
+//
+// It is intended to be used by custom Series that implement encoding.BinaryUnmarshaler.
+func LookupValueFormatter(name string) ValueToStringFormatter {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	if f, exists := formatters[name]; exists {
+		return f
+	}
+	return DefaultValueFormatter
+}
+
+// registeredName returns the name that the type of s was registered under using RegisterSeries.
+func registeredName(s Series) (string, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	name, exists := seriesNames[reflect.TypeOf(s)]
+	return name, exists
+}
+
+// newRegistered returns a new zero value of the Series registered under name.
+func newRegistered(name string) (Series, bool) {
+	registryLock.RLock()
+	t, exists := seriesTypes[name]
+	registryLock.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+	return reflect.New(t.Elem()).Interface().(Series), true
+}
+
+type dataFrameBinary struct {
+	Series []seriesBinary
+}
+
+type seriesBinary struct {
+	Type string
+	Data []byte
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The DataFrame can also be encoded using the gob package.
+// Each Series must be registered using RegisterSeries. The built-in Series are registered automatically.
+func (df *DataFrame) MarshalBinary() ([]byte, error) {
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	out := dataFrameBinary{Series: make([]seriesBinary, 0, len(df.Series))}
+
+	for _, s := range df.Series {
+		name, exists := registeredName(s)
+		if !exists {
+			return nil, &SeriesError{Series: s.Name(), Err: fmt.Errorf("%T is not registered", s)}
+		}
+
+		data, err := s.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, &SeriesError{Series: s.Name(), Err: err}
+		}
+
+		out.Series = append(out.Series, seriesBinary{Type: name, Data: data})
+	}
+
+	return gobEncode(out)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The existing Series of the DataFrame are replaced.
+func (df *DataFrame) UnmarshalBinary(data []byte) error {
+	var in dataFrameBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	seriess := make([]Series, 0, len(in.Series))
+
+	for idx, sb := range in.Series {
+		s, exists := newRegistered(sb.Type)
+		if !exists {
+			return fmt.Errorf("series: %d: %s is not registered", idx, sb.Type)
+		}
+
+		if err := s.(encoding.BinaryUnmarshaler).UnmarshalBinary(sb.Data); err != nil {
+			return fmt.Errorf("series: %d: %w", idx, err)
+		}
+
+		seriess = append(seriess, s)
+	}
+
+	n, err := checkSeries(seriess)
+	if err != nil {
+		return err
+	}
+
+	df.lock.Lock()
+	defer df.lock.Unlock()
+
+	df.Series = seriess
+	df.n = n
+
+	return nil
+}
+
+// checkSeries returns the number of rows of the decoded Series. An error is returned if the
+// Series don't have unique names or the same number of rows.
+func checkSeries(seriess []Series) (int, error) {
+	var n int
+	names := map[string]struct{}{}
+
+	for idx, s := range seriess {
+		name := s.Name(dontLock)
+		if _, exists := names[name]; exists {
+			return 0, &SeriesError{Series: name, Err: errors.New("names of series must be unique")}
+		}
+		names[name] = struct{}{}
+
+		if idx == 0 {
+			n = s.NRows(dontLock)
+		} else if s.NRows(dontLock) != n {
+			return 0, &SeriesError{Series: name, Err: errors.New("different number of rows in series")}
+		}
+	}
+	return n, nil
+}
+
+func gobEncode(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -544,6 +544,16 @@ func (df *DataFrame) Unlock(deepUnlock ...bool) {
 	df.lock.Unlock()
 }
 
+// RLock will lock the Dataframe for reading. Other readers are not blocked.
+func (df *DataFrame) RLock() {
+	df.lock.RLock()
+}
+
+// RUnlock will unlock the Dataframe that was previously locked for reading.
+func (df *DataFrame) RUnlock() {
+	df.lock.RUnlock()
+}
+
 // Copy will create a new copy of the Dataframe.
 // It is recommended that you lock the Dataframe
 // before attempting to Copy.
@@ -569,6 +579,35 @@ func (df *DataFrame) Copy(r ...Range) *DataFrame {
 	return newDF
 }
 
+// View returns a Dataframe that shares the underlying storage of df for the rows in r (zero-copy).
+// The storage of a Series is copied (copy-on-write) when either Dataframe is subsequently modified.
+// Series that don't implement the Viewer interface are copied.
+func (df *DataFrame) View(r Range, opts ...Options) *DataFrame {
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	seriess := []Series{}
+	for i := range df.Series {
+		if v, ok := df.Series[i].(Viewer); ok {
+			seriess = append(seriess, v.View(r))
+		} else {
+			seriess = append(seriess, df.Series[i].Copy(r))
+		}
+	}
+
+	newDF := &DataFrame{
+		Series: seriess,
+	}
+
+	if len(seriess) > 0 {
+		newDF.n = seriess[0].NRows(dontLock)
+	}
+
+	return newDF
+}
+
 // FillRand will randomly fill all the Series in the Dataframe.
 func (df *DataFrame) FillRand(src rand.Source, probNil float64, rander Rander, opts ...FillRandOptions) {
 	for _, s := range df.Series {
@@ -620,5 +659,10 @@ func (df *DataFrame) IsEqual(ctx context.Context, df2 *DataFrame, opts ...IsEqua
 		return false, err
 	}
 
+	// Compare rows
+	if len(opts) != 0 && opts[0].Unordered {
+		return df.isEqualUnordered(ctx, df2, opts[0])
+	}
+
 	return true, nil
 }
@@ -0,0 +1,257 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTMLOptions modifies the behavior of HTML and SeriesHTML.
+type HTMLOptions struct {
+	TableOptions
+
+	// Class is the CSS class of the table element. The default is "dataframe".
+	Class string
+
+	// NilClass is the CSS class of cells containing a nil value. The default is "nil".
+	NilClass string
+
+	// NilString replaces the text of cells containing a nil value. When blank, the Series' formatting is used.
+	NilString string
+
+	// NoFooter can be set to true to omit the footer containing the dimensions and the types of the Series.
+	NoFooter bool
+}
+
+func (o HTMLOptions) class() string {
+	if o.Class == "" {
+		return "dataframe"
+	}
+	return o.Class
+}
+
+func (o HTMLOptions) nilClass() string {
+	if o.NilClass == "" {
+		return "nil"
+	}
+	return o.NilClass
+}
+
+// HTML will produce the DataFrame in an HTML table. All names and values are escaped.
+//
+// Example:
+//
+//  r := dataframe.RangeFinite(0, 9)
+//  s := df.HTML(dataframe.HTMLOptions{TableOptions: dataframe.TableOptions{R: &r}, NilString: "-"})
+//
+func (df *DataFrame) HTML(opts ...HTMLOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, HTMLOptions{})
+	}
+
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	return renderHTML(tableSeries(df.Series, opts[0].Series), df.n, opts[0])
+}
+
+// Markdown will produce the DataFrame in a (GitHub flavored) Markdown table.
+// HTML special characters, pipe characters and new lines in names and values are escaped.
+func (df *DataFrame) Markdown(opts ...TableOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, TableOptions{})
+	}
+
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	return renderMarkdown(tableSeries(df.Series, opts[0].Series), df.n, opts[0])
+}
+
+// SeriesHTML will produce the Series in an HTML table. All names and values are escaped.
+// The Series option is ignored.
+func SeriesHTML(s Series, opts ...HTMLOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, HTMLOptions{})
+	}
+
+	if !opts[0].DontLock {
+		defer readLockSeries(s)()
+	}
+
+	return renderHTML([]Series{s}, s.NRows(dontLock), opts[0])
+}
+
+// SeriesMarkdown will produce the Series in a (GitHub flavored) Markdown table.
+// The Series option is ignored.
+func SeriesMarkdown(s Series, opts ...TableOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, TableOptions{})
+	}
+
+	if !opts[0].DontLock {
+		defer readLockSeries(s)()
+	}
+
+	return renderMarkdown([]Series{s}, s.NRows(dontLock), opts[0])
+}
+
+// readLockSeries locks s for reading and returns a function that unlocks it.
+// Series that don't implement RLocker are locked for writing.
+func readLockSeries(s Series) func() {
+	if rl, ok := s.(RLocker); ok {
+		rl.RLock()
+		return rl.RUnlock
+	}
+	s.Lock()
+	return s.Unlock
+}
+
+// tableSeries returns the Series selected by an index or name in cols (in their original order).
+// All Series are returned if cols is empty.
+func tableSeries(seriess []Series, cols []interface{}) []Series {
+	if len(cols) == 0 {
+		return seriess
+	}
+
+	columns := map[interface{}]struct{}{}
+	for _, v := range cols {
+		columns[v] = struct{}{}
+	}
+
+	out := []Series{}
+	for idx, s := range seriess {
+		if _, exists := columns[idx]; exists {
+			out = append(out, s)
+			continue
+		}
+		if _, exists := columns[s.Name(dontLock)]; exists {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// tableRows returns the first and last row to display. ok is false if there are no rows.
+func tableRows(n int, r *Range) (start, end int, ok bool) {
+	if n == 0 {
+		return 0, 0, false
+	}
+
+	if r == nil {
+		r = &Range{}
+	}
+
+	start, end, err := r.Limits(n)
+	if err != nil {
+		panic(err)
+	}
+	return start, end, true
+}
+
+func renderHTML(seriess []Series, n int, opts HTMLOptions) string {
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<table class=\"%s\">\n", html.EscapeString(opts.class()))
+
+	// Header
+	b.WriteString("  <thead>\n    <tr>\n      <th></th>\n") // row header is blank
+	for _, s := range seriess {
+		fmt.Fprintf(&b, "      <th>%s</th>\n", html.EscapeString(s.Name(dontLock)))
+	}
+	b.WriteString("    </tr>\n  </thead>\n")
+
+	// Body
+	b.WriteString("  <tbody>\n")
+	if start, end, ok := tableRows(n, opts.R); ok {
+		for row := start; row <= end; row++ {
+			fmt.Fprintf(&b, "    <tr>\n      <th>%d</th>\n", row)
+			for _, s := range seriess {
+				if s.Value(row, dontLock) == nil {
+					val := opts.NilString
+					if val == "" {
+						val = s.ValueString(row, dontLock)
+					}
+					fmt.Fprintf(&b, "      <td class=\"%s\">%s</td>\n", html.EscapeString(opts.nilClass()), html.EscapeString(val))
+				} else {
+					fmt.Fprintf(&b, "      <td>%s</td>\n", html.EscapeString(s.ValueString(row, dontLock)))
+				}
+			}
+			b.WriteString("    </tr>\n")
+		}
+	}
+	b.WriteString("  </tbody>\n")
+
+	// Footer
+	if !opts.NoFooter {
+		fmt.Fprintf(&b, "  <tfoot>\n    <tr>\n      <td>%dx%d</td>\n", n, len(seriess))
+		for _, s := range seriess {
+			fmt.Fprintf(&b, "      <td>%s</td>\n", html.EscapeString(s.Type()))
+		}
+		b.WriteString("    </tr>\n  </tfoot>\n")
+	}
+
+	b.WriteString("</table>\n")
+
+	return b.String()
+}
+
+// markdownEscape escapes s so that it can be placed in a cell of a Markdown table.
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(html.EscapeString(s))
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+func renderMarkdown(seriess []Series, n int, opts TableOptions) string {
+
+	var b strings.Builder
+
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, c := range cells {
+			b.WriteString(" " + c + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	// Header
+	headers := []string{""} // row header is blank
+	align := []string{"---:"}
+	for _, s := range seriess {
+		headers = append(headers, markdownEscape(s.Name(dontLock)))
+		align = append(align, ":---:")
+	}
+	writeRow(headers)
+	writeRow(align)
+
+	// Body
+	if start, end, ok := tableRows(n, opts.R); ok {
+		for row := start; row <= end; row++ {
+			cells := []string{fmt.Sprintf("%d", row)}
+			for _, s := range seriess {
+				cells = append(cells, markdownEscape(s.ValueString(row, dontLock)))
+			}
+			writeRow(cells)
+		}
+	}
+
+	return b.String()
+}
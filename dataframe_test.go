@@ -3,9 +3,19 @@
 package dataframe
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -162,6 +172,168 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+func TestView(t *testing.T) {
+	ctx := context.Background()
+
+	s1 := NewSeriesInt64("day", nil, 1, 2, 3, 4)
+	s2 := NewSeriesFloat64("sales", nil, 50.3, 23.4, 56.2, 100)
+	df := NewDataFrame(s1, s2)
+
+	view := df.View(Range{Start: &[]int{2}[0]})
+
+	expected := NewDataFrame(
+		NewSeriesInt64("day", nil, 3, 4),
+		NewSeriesFloat64("sales", nil, 56.2, 100),
+	)
+
+	if eq, _ := view.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, view)
+	}
+
+	// Storage is shared
+	if &s2.Values[2] != &view.Series[1].(*SeriesFloat64).Values[0] {
+		t.Errorf("storage not shared")
+	}
+
+	// Copy-on-write
+	view.UpdateRow(0, nil, map[string]interface{}{"day": 30, "sales": 5.0})
+	view.Append(nil, 5, 6.0)
+
+	if df.Series[0].Value(2) != int64(3) || df.Series[1].Value(2) != 56.2 || df.NRows() != 4 {
+		t.Errorf("wrong val: df modified by view")
+	}
+
+	if view.Series[0].Value(0) != int64(30) || view.NRows() != 3 {
+		t.Errorf("wrong val: view not modified")
+	}
+}
+
+func TestLazy(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesString("city", nil, "Sydney", "Melbourne", "Sydney", "Perth", "Melbourne"),
+		NewSeriesInt64("year", nil, 2020, 2020, 2021, 2021, 2021),
+		NewSeriesFloat64("sales", nil, 10.0, 20.0, 30.0, nil, 50.0),
+		NewSeriesString("notes", nil, "a", "b", "c", "d", "e"),
+	)
+
+	after2020 := func(vals map[interface{}]interface{}, row, nRows int) (FilterAction, error) {
+		if vals["year"].(int64) > 2020 {
+			return KEEP, nil
+		}
+		return DROP, nil
+	}
+
+	notPerth := func(vals map[interface{}]interface{}, row, nRows int) (FilterAction, error) {
+		if vals["city"] == "Perth" {
+			return DROP, nil
+		}
+		return KEEP, nil
+	}
+
+	lf := df.Lazy().
+		Select("city", "year", "sales").
+		Filter(after2020, "year").
+		Filter(notPerth, "city").
+		GroupBy([]string{"city"}, Aggregation{Series: "sales", Name: "total", Fn: AggSum}, Aggregation{Series: "sales", Name: "n", Fn: AggCount})
+
+	// Filters are fused and pushed down. Unused Series are pruned.
+	expectedPlan := `GROUPBY [city] AGG [total n]
+  SELECT [city year sales]
+    SCAN DataFrame COLUMNS [city year sales] FILTER [year city]`
+
+	if plan := lf.Explain(); plan != expectedPlan {
+		t.Errorf("wrong plan: expected: %v got: %v", expectedPlan, plan)
+	}
+
+	got, err := lf.Collect(ctx)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	expected := NewDataFrame(
+		NewSeriesString("city", nil, "Sydney", "Melbourne"),
+		NewSeriesFloat64("total", nil, 30.0, 50.0),
+		NewSeriesFloat64("n", nil, 1.0, 1.0),
+	)
+
+	if eq, _ := got.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v got: %v", expected, got)
+	}
+
+	// Filter on a group key is moved before the GroupBy
+	lf = df.Lazy().
+		GroupBy([]string{"city", "year"}, Aggregation{Series: "sales", Fn: AggMean}).
+		Filter(after2020, "year")
+
+	expectedPlan = `GROUPBY [city year] AGG [sales]
+  SCAN DataFrame COLUMNS [city year sales] FILTER [year]`
+
+	if plan := lf.Explain(); plan != expectedPlan {
+		t.Errorf("wrong plan: expected: %v got: %v", expectedPlan, plan)
+	}
+
+	got, err = lf.Collect(ctx)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	expected = NewDataFrame(
+		NewSeriesString("city", nil, "Sydney", "Perth", "Melbourne"),
+		NewSeriesInt64("year", nil, 2021, 2021, 2021),
+		NewSeriesFloat64("sales", nil, 30.0, nil, 50.0),
+	)
+
+	if eq, _ := got.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v got: %v", expected, got)
+	}
+
+	// Series required by later operations are not pruned below a Select
+	lf = df.Lazy().Select("city", "year").Select("city")
+
+	expectedPlan = `SELECT [city]
+  SELECT [city year]
+    SCAN DataFrame COLUMNS [city year]`
+
+	if plan := lf.Explain(); plan != expectedPlan {
+		t.Errorf("wrong plan: expected: %v got: %v", expectedPlan, plan)
+	}
+
+	got, err = lf.Collect(ctx)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	expected = NewDataFrame(NewSeriesString("city", nil, "Sydney", "Melbourne", "Sydney", "Perth", "Melbourne"))
+
+	if eq, _ := got.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v got: %v", expected, got)
+	}
+
+	got, err = df.Lazy().
+		Select("city", "year", "sales").
+		GroupBy([]string{"city"}, Aggregation{Series: "year", Fn: AggCount}).
+		Collect(ctx)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	expected = NewDataFrame(
+		NewSeriesString("city", nil, "Sydney", "Melbourne", "Perth"),
+		NewSeriesFloat64("year", nil, 2.0, 2.0, 1.0),
+	)
+
+	if eq, _ := got.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v got: %v", expected, got)
+	}
+
+	// Source is unmodified
+	if df.NRows() != 5 || len(df.Series) != 4 {
+		t.Errorf("wrong val: source modified")
+	}
+}
+
 func TestSort(t *testing.T) {
 
 	s1 := NewSeriesInt64("day", nil, nil, 1, 2, 4, 3, nil)
@@ -224,3 +396,862 @@ func TestDfIsEqual(t *testing.T) {
 		t.Errorf("Df1: [%T] %s is not equal to Df2: [%T] %s\n", df1, df1.String(), df2, df2.String())
 	}
 }
+
+func TestSortNilsAndArgSort(t *testing.T) {
+	ctx := context.Background()
+
+	s1 := NewSeriesInt64("day", nil, nil, 1, 2, 4, 3, nil)
+	s2 := NewSeriesFloat64("sales", nil, nil, 50.3, 23.4, 23.4, 56.2, 10.0)
+	df := NewDataFrame(s1, s2)
+
+	sks := []SortKey{
+		{Key: "sales", Desc: true, Nils: NilsLast},
+		{Key: "day", IsLessThanFunc: func(a, b interface{}) bool {
+			// Reverse order
+			return a.(int64) > b.(int64)
+		}},
+	}
+
+	perm, err := df.ArgSort(ctx, sks, SortOptions{Stable: true})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := []int{4, 1, 3, 2, 5, 0}
+	if !cmp.Equal(expected, perm) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, perm)
+	}
+
+	// df must be unmodified
+	if df.Series[0].Value(0) != nil || df.Series[0].Value(1) != int64(1) {
+		t.Errorf("ArgSort modified the DataFrame")
+	}
+
+	// Invalid keys
+	for _, key := range []interface{}{"missing", 5, 1.0} {
+		if _, err := df.ArgSort(ctx, []SortKey{{Key: key}}); err == nil {
+			t.Errorf("wrong err: %v: expected: %v got: %v", key, "error", err)
+		}
+	}
+
+	df.Sort(ctx, sks, SortOptions{Stable: true})
+
+	expectedValues := []interface{}{int64(3), int64(1), int64(4), int64(2), nil, nil}
+	for row, expected := range expectedValues {
+		actual := df.Series[0].Value(row)
+		if !cmp.Equal(expected, actual) {
+			t.Errorf("wrong val: expected: %T %v actual: %T %v", expected, expected, actual, actual)
+		}
+	}
+
+	// Series sort
+	s3 := NewSeriesFloat64("sales", nil, nil, 50.3, 23.4, nil, 56.2)
+	s3.Sort(ctx, SortOptions{Nils: NilsLast})
+
+	expectedValues = []interface{}{23.4, 50.3, 56.2, nil, nil}
+	for row, expected := range expectedValues {
+		actual := s3.Value(row)
+		if !cmp.Equal(expected, actual) {
+			t.Errorf("wrong val: expected: %T %v actual: %T %v", expected, expected, actual, actual)
+		}
+	}
+}
+
+func TestParallelAndExternalSort(t *testing.T) {
+	ctx := context.Background()
+
+	const N = 10000
+
+	src := rand.NewSource(1)
+	newDF := func() *DataFrame {
+		s1 := NewSeriesInt64("day", &SeriesInit{Size: N})
+		s2 := NewSeriesFloat64("sales", &SeriesInit{Size: N})
+		df := NewDataFrame(s1, s2)
+		src.Seed(1)
+		df.FillRand(src, 0.1, distuv.Uniform{Min: 0, Max: 100, Src: src})
+		return df
+	}
+
+	sks := []SortKey{
+		{Key: "day", Desc: true},
+		{Key: "sales"},
+	}
+
+	expected := newDF()
+	expected.Sort(ctx, sks, SortOptions{Stable: true})
+
+	for _, opts := range []SortOptions{
+		{Parallel: true},
+		{MemoryBudget: 8 * 1024},
+		{MemoryBudget: 8 * 1024, Parallel: true},
+	} {
+		df := newDF()
+		if !df.Sort(ctx, sks, opts) {
+			t.Errorf("sort not completed: %+v", opts)
+		}
+
+		eq, err := expected.IsEqual(ctx, df)
+		if err != nil {
+			t.Errorf("error encountered: %s\n", err)
+		}
+		if !eq {
+			t.Errorf("wrong val: %+v: expected: %v actual: %v", opts, expected, df)
+		}
+	}
+
+	// ArgSort (external)
+	df := newDF()
+	perm, err := df.ArgSort(ctx, sks, SortOptions{MemoryBudget: 8 * 1024})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	for i, row := range perm {
+		if expected.Series[1].Value(i) != df.Series[1].Value(row) {
+			t.Fatalf("wrong val: %d: expected: %v actual: %v", i, expected.Series[1].Value(i), df.Series[1].Value(row))
+		}
+	}
+
+	// Spill errors are returned (and the Dataframe is not modified)
+	opts := SortOptions{MemoryBudget: 8 * 1024, TempDir: filepath.Join(t.TempDir(), "missing")}
+
+	if _, err := df.ArgSort(ctx, sks, opts); err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+
+	if df.Sort(ctx, sks, opts) {
+		t.Errorf("sort completed with missing temp dir")
+	}
+
+	if err := df.SortErr(ctx, sks, opts); err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+
+	if err := df.SortErr(ctx, []SortKey{{Key: "unknown"}}); err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := df.SortErr(cctx, sks, SortOptions{MemoryBudget: 8 * 1024}); err != context.Canceled {
+		t.Errorf("wrong err: expected: %v got: %v", context.Canceled, err)
+	}
+
+	if eq, _ := newDF().IsEqual(ctx, df); !eq {
+		t.Errorf("wrong val: Dataframe modified")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	ctx := context.Background()
+
+	s1 := NewSeriesString("name", nil, "a", "b")
+	s2 := NewSeriesInt64("q1", nil, 1, 3)
+	s3 := NewSeriesFloat64("q2", nil, 2.5, nil)
+	df := NewDataFrame(s1, s2, s3)
+
+	tdf, err := Transpose(ctx, df, "name")
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := `+-----+--------+---------+---------+
+|     |  NAME  |    A    |    B    |
++-----+--------+---------+---------+
+| 0:  |   q1   |    1    |    3    |
+| 1:  |   q2   |   2.5   |   NaN   |
++-----+--------+---------+---------+
+| 2X3 | STRING | FLOAT64 | FLOAT64 |
++-----+--------+---------+---------+`
+
+	if strings.TrimSpace(tdf.Table()) != strings.TrimSpace(expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, tdf.Table())
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2, 2, 4),
+		NewSeriesFloat64("price", nil, 10.5, -1.0, nil, 3.0),
+		NewSeriesString("currency", nil, "AUD", "USD", "NZD", "AUD"),
+		NewSeriesString("extra", nil, "a", "b", "c", "d"),
+	)
+
+	schema := Schema{
+		Fields: []SchemaField{
+			{Name: "id", Type: int64(0), Unique: true},
+			{Name: "price", Type: float64(0), Min: 0, Nullable: true},
+			{Name: "currency", Type: "", Enum: []interface{}{"AUD", "USD"}},
+			{Name: "missing", Type: ""},
+		},
+		Strict: true,
+	}
+
+	err := schema.Validate(ctx, df)
+	if err == nil {
+		t.Fatalf("expected validation errors")
+	}
+
+	expected := []string{
+		"row: 2: series: id: duplicate value",
+		"row: 1: series: price: out of range",
+		"row: 2: series: currency: not an allowed value",
+		"series: missing: series missing",
+		"series: extra: series unexpected",
+	}
+
+	actual := []string{}
+	for _, e := range err.(*ErrorCollection).Errors() {
+		actual = append(actual, e.Error())
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("wrong errors: expected: %v actual: %v", expected, actual)
+	}
+
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("expected ErrOutOfRange")
+	}
+
+	dt := schema.DictateDataType()
+	if len(dt) != 4 || dt["price"] != float64(0) {
+		t.Errorf("wrong DictateDataType: %v", dt)
+	}
+
+	// int is normalized for the imports package
+	dt = Schema{Fields: []SchemaField{{Name: "n", Type: 0}}}.DictateDataType()
+	if dt["n"] != int64(0) {
+		t.Errorf("wrong DictateDataType: %v", dt)
+	}
+}
+
+func TestStructs(t *testing.T) {
+	ctx := context.Background()
+
+	type Base struct {
+		ID int `dataframe:"id"`
+	}
+
+	type Order struct {
+		Base
+		Customer string        `dataframe:"customer"`
+		Total    *float64      `dataframe:"total"`
+		Shipped  time.Time     `dataframe:"shipped,omitempty"`
+		Duration time.Duration `dataframe:"duration"`
+		Paid     bool          `dataframe:"paid"`
+		Ignored  string        `dataframe:"-"`
+		internal string
+	}
+
+	total := 12.5
+	shipped := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := []Order{
+		{Base: Base{1}, Customer: "alice", Total: &total, Shipped: shipped, Duration: time.Hour, Paid: true, Ignored: "x"},
+		{Base: Base{2}, Customer: "bob"},
+	}
+
+	df, err := FromStructs(ctx, orders)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expDf := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2),
+		NewSeriesString("customer", nil, "alice", "bob"),
+		NewSeriesFloat64("total", nil, 12.5, nil),
+		NewSeriesTime("shipped", nil, shipped, nil),
+		NewSeriesDuration("duration", nil, time.Hour, time.Duration(0)),
+		NewSeriesInt64("paid", nil, 1, 0),
+	)
+
+	if eq, err := df.IsEqual(ctx, expDf, IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong df: expected: %v actual: %v", expDf.String(), df.String())
+	}
+
+	decoded := []*Order{}
+	if err := df.ToStructs(ctx, &decoded); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	orders[0].Ignored = ""
+	for i := range orders {
+		if !cmp.Equal(orders[i], *decoded[i], cmpopts.IgnoreUnexported(Order{})) {
+			t.Errorf("wrong struct: expected: %v actual: %v", orders[i], *decoded[i])
+		}
+	}
+
+	var o Order
+	if err := df.RowInto(1, &o); err != nil || o.Customer != "bob" || o.Total != nil {
+		t.Errorf("wrong struct: %v %v", o, err)
+	}
+
+	// Shadowed and ambiguous fields (encoding/json rules)
+	type A struct {
+		ID   int
+		Name string
+		Code string
+	}
+
+	type B struct {
+		Name string
+		Code string `dataframe:"Code"`
+	}
+
+	type c struct {
+		Hidden int
+	}
+
+	type Shadow struct {
+		A
+		*B
+		*c
+		ID int
+	}
+
+	df, err = FromStructs(ctx, []Shadow{{A: A{ID: 1, Name: "a", Code: "x"}, B: &B{Name: "b", Code: "y"}, ID: 2}})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expDf = NewDataFrame(
+		NewSeriesString("Code", nil, "y"),
+		NewSeriesInt64("ID", nil, 2),
+	)
+
+	if eq, err := df.IsEqual(ctx, expDf, IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong df: expected: %v actual: %v", expDf.String(), df.String())
+	}
+
+	var sh Shadow
+	if err := df.RowInto(0, &sh); err != nil || sh.ID != 2 || sh.A.ID != 0 || sh.B == nil || sh.B.Code != "y" {
+		t.Errorf("wrong struct: %+v %v", sh, err)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	ctx := context.Background()
+
+	loc, _ := time.LoadLocation("Australia/Melbourne")
+	t1 := time.Date(2021, 1, 2, 3, 4, 5, 0, loc)
+
+	st := NewSeriesTime("time", nil, t1, nil)
+	st.Layout = "2006-01-02"
+	st.Location = loc
+
+	si := NewSeriesInt64("bool", nil, 1, nil)
+	si.SetValueToStringFormatter(BoolValueFormatter)
+
+	df := NewDataFrame(
+		NewSeriesFloat64("float", nil, 1.5, nil),
+		si,
+		NewSeriesString("string", nil, nil, "b"),
+		st,
+		NewSeriesDuration("duration", nil, time.Second, nil),
+		NewSeriesMixed("mixed", nil, "a", 2.0),
+		NewSeriesGeneric("generic", "", nil, nil, "b"),
+	)
+
+	data, err := df.MarshalBinary()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	got := NewDataFrame()
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, got, IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong df: expected: %v actual: %v", df.String(), got.String())
+	}
+
+	gotTime := got.Series[3].(*SeriesTime)
+	if gotTime.Layout != st.Layout || gotTime.Location.String() != loc.String() {
+		t.Errorf("wrong time: expected: %v %v actual: %v %v", st.Layout, loc, gotTime.Layout, gotTime.Location)
+	}
+
+	if v := got.Series[1].ValueString(0); v != "true" {
+		t.Errorf("wrong formatter: expected: %v actual: %v", "true", v)
+	}
+
+	if n, _ := got.Series[0].NilCount(); n != 1 {
+		t.Errorf("wrong nil count: expected: %v actual: %v", 1, n)
+	}
+
+	// gob
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(map[string]Series{"s": st}); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	out := map[string]Series{}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, err := st.IsEqual(ctx, out["s"], IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong series: expected: %v actual: %v", st.String(), out["s"])
+	}
+
+	// Fixed zone
+	fz := time.FixedZone("AEST", 10*60*60)
+	sf := NewSeriesTime("time", nil, t1, nil)
+	sf.Location = fz
+
+	data, err = sf.MarshalBinary()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	gotFixed := &SeriesTime{}
+	if err := gotFixed.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if name, offset := gotFixed.Value(0).(time.Time).Zone(); name != "AEST" || offset != 10*60*60 || gotFixed.Location.String() != "AEST" {
+		t.Errorf("wrong zone: expected: %v %v actual: %v %v", "AEST", 10*60*60, name, offset)
+	}
+
+	if eq, err := sf.IsEqual(ctx, gotFixed, IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong series: expected: %v actual: %v", sf.String(), gotFixed.String())
+	}
+
+	// The comparison functions of a SeriesGeneric are retained
+	sg := NewSeriesGeneric("generic", "", nil, "b", "a")
+	data, err = sg.MarshalBinary()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	gotGeneric := NewSeriesGeneric("", "", nil)
+	gotGeneric.SetIsLessThanFunc(func(a, b interface{}) bool { return a.(string) < b.(string) })
+	if err := gotGeneric.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if !gotGeneric.IsLessThanFunc("a", "b") {
+		t.Errorf("wrong val: expected: %v actual: %v", true, false)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t1 := time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	st := NewSeriesTime("time", nil, t1, nil)
+	st.Layout = "2006-01-02"
+	st.Location = time.UTC
+
+	df := NewDataFrame(
+		NewSeriesFloat64("float", nil, math.Inf(1), nil),
+		NewSeriesInt64("int", nil, 1, nil),
+		NewSeriesString("string", nil, nil, "b"),
+		st,
+		NewSeriesDuration("duration", nil, time.Second, nil),
+		NewSeriesMixed("mixed", nil, "a", 2.0),
+		NewSeriesGeneric("generic", "", nil, nil, "b"),
+	)
+
+	data, err := json.Marshal(df)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	// SeriesGeneric requires a template
+	got := NewDataFrame(NewSeriesGeneric("generic", "", nil))
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, got, IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong df: expected: %v actual: %v", df.String(), got.String())
+	}
+
+	if got.Series[3].(*SeriesTime).Layout != st.Layout {
+		t.Errorf("wrong layout: expected: %v actual: %v", st.Layout, got.Series[3].(*SeriesTime).Layout)
+	}
+
+	// Without a template
+	if err := json.Unmarshal(data, NewDataFrame()); err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+
+	// Fixed zone
+	fz := time.FixedZone("AEST", 10*60*60)
+	sf := NewSeriesTime("time", nil, t1, nil)
+	sf.Location = fz
+
+	data, err = json.Marshal(sf)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	gotFixed := &SeriesTime{}
+	if err := json.Unmarshal(data, gotFixed); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if name, offset := gotFixed.Value(0).(time.Time).Zone(); name != "AEST" || offset != 10*60*60 || gotFixed.Location.String() != "AEST" {
+		t.Errorf("wrong zone: expected: %v %v actual: %v %v", "AEST", 10*60*60, name, offset)
+	}
+
+	if eq, err := sf.IsEqual(ctx, gotFixed, IsEqualOptions{CheckName: true}); err != nil || !eq {
+		t.Errorf("wrong series: expected: %v actual: %v", sf.String(), gotFixed.String())
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+
+	a := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2, 3),
+		NewSeriesString("name", nil, "alice", "bob", "carol"),
+		NewSeriesFloat64("score", nil, 1.0, 2.0, nil),
+		NewSeriesInt64("removed", nil, 1, 2, 3),
+		NewSeriesInt64("typ", nil, 1, 2, 3),
+	)
+
+	b := NewDataFrame(
+		NewSeriesInt64("id", nil, 4, 2, 1),
+		NewSeriesString("name", nil, "dave", "bob", "alice"),
+		NewSeriesFloat64("score", nil, 4.0, 2.5, 1.0),
+		NewSeriesFloat64("typ", nil, 1, 2, 3),
+		NewSeriesString("added", nil, "x", "y", "z"),
+	)
+
+	report, err := Diff(ctx, a, b, DiffOptions{Keys: []string{"id"}})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := []Difference{
+		{Kind: SeriesRemoved, Series: "removed"},
+		{Kind: TypeChanged, Series: "typ", A: "int64", B: "float64"},
+		{Kind: SeriesAdded, Series: "added"},
+		{Kind: ValueChanged, Series: "score", RowA: &[]int{1}[0], RowB: &[]int{1}[0], Key: []interface{}{int64(2)}, A: 2.0, B: 2.5},
+		{Kind: RowRemoved, RowA: &[]int{2}[0], Key: []interface{}{int64(3)}},
+		{Kind: RowAdded, RowB: &[]int{0}[0], Key: []interface{}{int64(4)}},
+	}
+
+	if !cmp.Equal(report.Differences, expected) {
+		t.Errorf("wrong differences: %s", cmp.Diff(expected, report.Differences))
+	}
+
+	rdf := report.DataFrame()
+	if rdf.NRows() != len(expected) || len(rdf.Series) != 7 {
+		t.Errorf("wrong df: %v", rdf)
+	}
+
+	// Aligned by position
+	report, err = Diff(ctx, a, a.Copy())
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if !report.Equal() {
+		t.Errorf("wrong differences: %v", report.Table())
+	}
+
+	// Duplicate keys
+	c := NewDataFrame(NewSeriesInt64("id", nil, 1, 1))
+	_, err = Diff(ctx, a, c, DiffOptions{Keys: []string{"id"}})
+	if err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+	// Keys containing the same instant in different locations
+	loc, _ := time.LoadLocation("Australia/Melbourne")
+	t1 := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	d := NewDataFrame(NewSeriesTime("time", nil, t1, t1.Add(time.Hour)))
+	e := NewDataFrame(NewSeriesTime("time", nil, t1.Add(time.Hour).In(loc), t1.In(loc)))
+
+	report, err = Diff(ctx, d, e, DiffOptions{Keys: []string{"time"}})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if !report.Equal() {
+		t.Errorf("wrong differences: %v", report.Table())
+	}
+}
+
+func TestIsEqualUnordered(t *testing.T) {
+	ctx := context.Background()
+
+	df1 := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2, 3),
+		NewSeriesFloat64("score", nil, 1.0, 2.0, 3.0),
+	)
+
+	df2 := NewDataFrame(
+		NewSeriesInt64("id", nil, 3, 1, 2),
+		NewSeriesFloat64("score", nil, 3.0, 1.0, 2.0+1e-12),
+	)
+
+	if eq, _ := df1.IsEqual(ctx, df2, IsEqualOptions{Unordered: true}); eq {
+		t.Errorf("wrong val: expected: %v actual: %v", false, eq)
+	}
+
+	if eq, _ := df1.IsEqual(ctx, df2, IsEqualOptions{Unordered: true, AbsTol: 1e-9}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", true, eq)
+	}
+
+	// Values match individually but rows don't
+	df3 := NewDataFrame(
+		NewSeriesInt64("id", nil, 3, 1, 2),
+		NewSeriesFloat64("score", nil, 1.0, 2.0, 3.0),
+	)
+
+	if eq, _ := df1.IsEqual(ctx, df3, IsEqualOptions{Unordered: true}); eq {
+		t.Errorf("wrong val: expected: %v actual: %v", false, eq)
+	}
+
+	// A valid pairing exists within the tolerance even though the sorted rows don't match pairwise
+	df4 := NewDataFrame(
+		NewSeriesFloat64("x", nil, 1.0, 1.05),
+		NewSeriesInt64("y", nil, 10, 20),
+	)
+
+	df5 := NewDataFrame(
+		NewSeriesFloat64("x", nil, 1.04, 1.06),
+		NewSeriesInt64("y", nil, 20, 10),
+	)
+
+	if eq, _ := df4.IsEqual(ctx, df5, IsEqualOptions{Unordered: true, AbsTol: 0.1}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", true, eq)
+	}
+
+	if eq, _ := df4.IsEqual(ctx, df5, IsEqualOptions{Unordered: true, AbsTol: 0.01}); eq {
+		t.Errorf("wrong val: expected: %v actual: %v", false, eq)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesString("dept", nil, "a", "b", "a", "a", "b"),
+		NewSeriesFloat64("salary", nil, 10.0, 5.0, 30.0, 10.0, nil),
+	)
+
+	err := Window(ctx, df, []string{"dept"}, []SortKey{{Key: "salary", Desc: true}}, []WindowFunc{
+		{Fn: RowNumber},
+		{Fn: Rank},
+		{Fn: DenseRank},
+		{Fn: Lag, Series: "salary"},
+		{Fn: Lead, Series: "salary"},
+		{Fn: FirstValue, Series: "salary"},
+		{Fn: LastValue, Series: "salary"},
+		{Fn: RunningSum, Series: "salary"},
+		{Fn: RunningAvg, Series: "salary", Name: "avg"},
+	})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := NewDataFrame(
+		NewSeriesString("dept", nil, "a", "b", "a", "a", "b"),
+		NewSeriesFloat64("salary", nil, 10.0, 5.0, 30.0, 10.0, nil),
+		NewSeriesInt64("row_number", nil, 2, 1, 1, 3, 2),
+		NewSeriesInt64("rank", nil, 2, 1, 1, 2, 2),
+		NewSeriesInt64("dense_rank", nil, 2, 1, 1, 2, 2),
+		NewSeriesFloat64("lag_salary", nil, 30.0, nil, nil, 10.0, 5.0),
+		NewSeriesFloat64("lead_salary", nil, 10.0, nil, 10.0, nil, nil),
+		NewSeriesFloat64("first_value_salary", nil, 30.0, 5.0, 30.0, 30.0, 5.0),
+		NewSeriesFloat64("last_value_salary", nil, 10.0, nil, 10.0, 10.0, nil),
+		NewSeriesFloat64("running_sum_salary", nil, 40.0, 5.0, 30.0, 50.0, 5.0),
+		NewSeriesFloat64("avg", nil, 20.0, 5.0, 30.0, 50.0/3, 5.0),
+	)
+
+	if eq, err := df.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v (%v)", expected.Table(), df.Table(), err)
+	}
+
+	// Duplicate name
+	err = Window(ctx, df, nil, nil, []WindowFunc{{Fn: RowNumber}})
+	if err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+
+	// Unknown orderBy key
+	err = Window(ctx, df, nil, []SortKey{{Key: "nope"}}, []WindowFunc{{Fn: RowNumber, Name: "rn"}})
+	if err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+}
+
+func TestExplode(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2, 3),
+		NewSeriesList("tags", NewSeriesString("", nil), nil, []string{"a", "b"}, nil, []string{}),
+	)
+
+	exploded, err := Explode(ctx, df, "tags")
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 1, 2, 3),
+		NewSeriesString("tags", nil, "a", "b", nil, nil),
+	)
+
+	if eq, err := exploded.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v (%v)", expected.Table(), exploded.Table(), err)
+	}
+
+	// Not a SeriesList
+	_, err = Explode(ctx, df, "id")
+	if err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	ctx := context.Background()
+
+	nested := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2),
+		NewSeriesStruct("a", nil,
+			NewSeriesString("b", nil, "x", "y"),
+			NewSeriesStruct("c", nil,
+				NewSeriesFloat64("d", nil, 1.0, nil),
+			),
+		),
+	)
+
+	flat := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2),
+		NewSeriesString("a_b", nil, "x", "y"),
+		NewSeriesFloat64("a_c_d", nil, 1.0, nil),
+	)
+
+	df, err := Flatten(ctx, nested, FlattenOptions{Separator: "_"})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, flat, IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v (%v)", flat.Table(), df.Table(), err)
+	}
+
+	df, err = Unflatten(ctx, flat, FlattenOptions{Separator: "_"})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, nested, IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v (%v)", nested.Table(), df.Table(), err)
+	}
+
+	// Name clash
+	_, err = Unflatten(ctx, NewDataFrame(NewSeriesInt64("a", nil, 1), NewSeriesInt64("a.b", nil, 1)))
+	if err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+}
+
+func TestHTML(t *testing.T) {
+
+	df := NewDataFrame(
+		NewSeriesString("<name>", nil, "a&b", nil, "c"),
+		NewSeriesInt64("age", nil, 1, 2, 3),
+	)
+
+	r := RangeFinite(0, 1)
+
+	expected := `<table class="report">
+  <thead>
+    <tr>
+      <th></th>
+      <th>&lt;name&gt;</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <th>0</th>
+      <td>a&amp;b</td>
+    </tr>
+    <tr>
+      <th>1</th>
+      <td class="nil">-</td>
+    </tr>
+  </tbody>
+  <tfoot>
+    <tr>
+      <td>3x1</td>
+      <td>string</td>
+    </tr>
+  </tfoot>
+</table>
+`
+
+	actual := df.HTML(HTMLOptions{TableOptions: TableOptions{Series: []interface{}{"<name>"}, R: &r}, Class: "report", NilString: "-"})
+	if actual != expected {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	// Series
+	expected = `<table class="dataframe">
+  <thead>
+    <tr>
+      <th></th>
+      <th>age</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <th>2</th>
+      <td>3</td>
+    </tr>
+  </tbody>
+</table>
+`
+
+	r = RangeFinite(2)
+	actual = SeriesHTML(df.Series[1], HTMLOptions{TableOptions: TableOptions{R: &r}, NoFooter: true})
+	if actual != expected {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+
+	df := NewDataFrame(
+		NewSeriesString("name", nil, "a|b", nil, "c\nd", "<i>&"),
+		NewSeriesInt64("age", nil, 1, 2, 3, 4),
+	)
+
+	expected := `|  | name | age |
+| ---: | :---: | :---: |
+| 0 | a\|b | 1 |
+| 1 | NaN | 2 |
+| 2 | c<br>d | 3 |
+| 3 | &lt;i&gt;&amp; | 4 |
+`
+
+	if actual := df.Markdown(); actual != expected {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	r := RangeFinite(1, 1)
+
+	expected = `|  | age |
+| ---: | :---: |
+| 1 | 2 |
+`
+
+	if actual := df.Markdown(TableOptions{Series: []interface{}{1}, R: &r}); actual != expected {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	if actual := SeriesMarkdown(df.Series[1], TableOptions{R: &r}); actual != expected {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+}
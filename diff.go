@@ -0,0 +1,371 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DiffOptions modifies the behavior of Diff.
+type DiffOptions struct {
+
+	// Keys is used to align the rows of the DataFrames using the values of the given Series.
+	// The Series must exist in both DataFrames and the combination of their values must be unique.
+	// When nil (default), rows are aligned by position.
+	Keys []string
+
+	// DontLock can be set to true if the DataFrames should not be locked.
+	DontLock bool
+}
+
+// DiffKind describes a difference between two DataFrames.
+type DiffKind string
+
+const (
+	// SeriesAdded signifies that a Series exists in b but not in a.
+	SeriesAdded DiffKind = "series added"
+
+	// SeriesRemoved signifies that a Series exists in a but not in b.
+	SeriesRemoved DiffKind = "series removed"
+
+	// TypeChanged signifies that a Series exists in both DataFrames but with a different type.
+	// The values of the Series are not compared.
+	TypeChanged DiffKind = "type changed"
+
+	// RowAdded signifies that a row exists in b but not in a.
+	RowAdded DiffKind = "row added"
+
+	// RowRemoved signifies that a row exists in a but not in b.
+	RowRemoved DiffKind = "row removed"
+
+	// ValueChanged signifies that the value of a cell is different.
+	ValueChanged DiffKind = "value changed"
+)
+
+// Difference records a single difference between two DataFrames.
+type Difference struct {
+	Kind DiffKind
+
+	// Series is the name of the Series. It is blank for RowAdded and RowRemoved.
+	Series string
+
+	// RowA and RowB are the rows in a and b respectively. They are nil when not applicable.
+	RowA *int
+	RowB *int
+
+	// Key contains the values of the key Series when DiffOptions.Keys is set.
+	Key []interface{}
+
+	// A and B are the values in a and b for ValueChanged. For TypeChanged, they are the types of the Series.
+	A interface{}
+	B interface{}
+}
+
+// DiffReport contains the differences between two DataFrames.
+type DiffReport struct {
+	Differences []Difference
+	keys        []string
+}
+
+// Equal returns true if no differences were found.
+func (r *DiffReport) Equal() bool {
+	return len(r.Differences) == 0
+}
+
+// DataFrame returns the differences as a DataFrame.
+//
+// The DataFrame contains the Series: kind, series, row_a, row_b, a and b.
+// A Series for each key is inserted after row_b when DiffOptions.Keys was set. The Series is prefixed
+// with "key_" if its name clashes with another Series.
+func (r *DiffReport) DataFrame() *DataFrame {
+	n := len(r.Differences)
+	init := &SeriesInit{Capacity: n}
+
+	kind := NewSeriesString("kind", init)
+	series := NewSeriesString("series", init)
+	rowA := NewSeriesInt64("row_a", init)
+	rowB := NewSeriesInt64("row_b", init)
+	a := NewSeriesString("a", init)
+	b := NewSeriesString("b", init)
+
+	keys := make([]*SeriesString, 0, len(r.keys))
+	for _, k := range r.keys {
+		if subset([]string{k}, []string{"kind", "series", "row_a", "row_b", "a", "b"}) {
+			k = "key_" + k
+		}
+		keys = append(keys, NewSeriesString(k, init))
+	}
+
+	for _, d := range r.Differences {
+		kind.Append(string(d.Kind), dontLock)
+		series.Append(blankToNil(d.Series), dontLock)
+		rowA.Append(intPtrVal(d.RowA), dontLock)
+		rowB.Append(intPtrVal(d.RowB), dontLock)
+
+		for idx, k := range keys {
+			if idx < len(d.Key) && d.Key[idx] != nil {
+				k.Append(fmt.Sprintf("%v", d.Key[idx]), dontLock)
+			} else {
+				k.Append(nil, dontLock)
+			}
+		}
+
+		if d.Kind == ValueChanged || d.Kind == TypeChanged {
+			a.Append(diffValueString(d.A), dontLock)
+			b.Append(diffValueString(d.B), dontLock)
+		} else {
+			a.Append(nil, dontLock)
+			b.Append(nil, dontLock)
+		}
+	}
+
+	seriess := []Series{kind, series, rowA, rowB}
+	for _, k := range keys {
+		seriess = append(seriess, k)
+	}
+	seriess = append(seriess, a, b)
+
+	return NewDataFrame(seriess...)
+}
+
+// Table will produce the differences in a table.
+func (r *DiffReport) Table() string {
+	return r.DataFrame().Table()
+}
+
+// String implements the fmt.Stringer interface.
+func (r *DiffReport) String() string {
+	if r.Equal() {
+		return "no differences"
+	}
+
+	counts := map[DiffKind]int{}
+	for _, d := range r.Differences {
+		counts[d.Kind]++
+	}
+
+	out := []string{}
+	for _, k := range []DiffKind{SeriesAdded, SeriesRemoved, TypeChanged, RowAdded, RowRemoved, ValueChanged} {
+		if counts[k] > 0 {
+			out = append(out, fmt.Sprintf("%s: %d", k, counts[k]))
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+// Diff reports the differences between a and b. Unlike IsEqual, it reports which Series were added or removed,
+// which Series changed type and which values changed. Series are matched by name.
+//
+// Example:
+//
+//  report, err := dataframe.Diff(ctx, expected, actual, dataframe.DiffOptions{Keys: []string{"id"}})
+//  if !report.Equal() {
+//     fmt.Println(report.Table())
+//  }
+//
+func Diff(ctx context.Context, a, b *DataFrame, opts ...DiffOptions) (*DiffReport, error) {
+
+	var options DiffOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if !options.DontLock {
+		a.lock.RLock()
+		defer a.lock.RUnlock()
+		if a != b {
+			b.lock.RLock()
+			defer b.lock.RUnlock()
+		}
+	}
+
+	report := &DiffReport{keys: options.Keys}
+
+	// Compare Series
+	type pair struct {
+		name string
+		a, b Series
+	}
+	common := []pair{}
+
+	for _, sa := range a.Series {
+		name := sa.Name(dontLock)
+		idx, err := b.NameToColumn(name, dontLock)
+		if err != nil {
+			report.Differences = append(report.Differences, Difference{Kind: SeriesRemoved, Series: name})
+			continue
+		}
+
+		sb := b.Series[idx]
+		if sa.Type() != sb.Type() {
+			report.Differences = append(report.Differences, Difference{Kind: TypeChanged, Series: name, A: sa.Type(), B: sb.Type()})
+			continue
+		}
+
+		if !subset([]string{name}, options.Keys) {
+			common = append(common, pair{name, sa, sb})
+		}
+	}
+
+	for _, sb := range b.Series {
+		name := sb.Name(dontLock)
+		if _, err := a.NameToColumn(name, dontLock); err != nil {
+			report.Differences = append(report.Differences, Difference{Kind: SeriesAdded, Series: name})
+		}
+	}
+
+	// Align rows
+	type rowPair struct {
+		a, b int // -1 if the row does not exist
+		key  []interface{}
+	}
+	rows := []rowPair{}
+
+	if len(options.Keys) == 0 {
+		for row := 0; row < a.n || row < b.n; row++ {
+			rp := rowPair{a: row, b: row}
+			if row >= a.n {
+				rp.a = -1
+			}
+			if row >= b.n {
+				rp.b = -1
+			}
+			rows = append(rows, rp)
+		}
+	} else {
+		keysA, err := diffKeys(ctx, a, options.Keys)
+		if err != nil {
+			return nil, err
+		}
+		keysB, err := diffKeys(ctx, b, options.Keys)
+		if err != nil {
+			return nil, err
+		}
+
+		idxB := make(map[string]int, len(keysB))
+		for row, k := range keysB {
+			idxB[k] = row
+		}
+
+		matched := make([]bool, b.n)
+		for row, k := range keysA {
+			if rowB, exists := idxB[k]; exists {
+				rows = append(rows, rowPair{a: row, b: rowB, key: diffKeyVals(a, options.Keys, row)})
+				matched[rowB] = true
+			} else {
+				rows = append(rows, rowPair{a: row, b: -1, key: diffKeyVals(a, options.Keys, row)})
+			}
+		}
+		for row := range keysB {
+			if !matched[row] {
+				rows = append(rows, rowPair{a: -1, b: row, key: diffKeyVals(b, options.Keys, row)})
+			}
+		}
+	}
+
+	// Compare values
+	for _, rp := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rowA, rowB := &[]int{rp.a}[0], &[]int{rp.b}[0]
+
+		if rp.a == -1 {
+			report.Differences = append(report.Differences, Difference{Kind: RowAdded, RowB: rowB, Key: rp.key})
+			continue
+		}
+		if rp.b == -1 {
+			report.Differences = append(report.Differences, Difference{Kind: RowRemoved, RowA: rowA, Key: rp.key})
+			continue
+		}
+
+		for _, p := range common {
+			va := p.a.Value(rp.a, dontLock)
+			vb := p.b.Value(rp.b, dontLock)
+
+			if !p.a.IsEqualFunc(va, vb) {
+				report.Differences = append(report.Differences, Difference{
+					Kind:   ValueChanged,
+					Series: p.name,
+					RowA:   rowA,
+					RowB:   rowB,
+					Key:    rp.key,
+					A:      va,
+					B:      vb,
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// diffKeys returns a unique string representation of the key for each row.
+func diffKeys(ctx context.Context, df *DataFrame, keys []string) ([]string, error) {
+
+	cols := make([]int, 0, len(keys))
+	for _, k := range keys {
+		idx, err := df.NameToColumn(k, dontLock)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, idx)
+	}
+
+	out := make([]string, 0, df.n)
+	seen := make(map[string]struct{}, df.n)
+
+	for row := 0; row < df.n; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		vals := make([]interface{}, 0, len(cols))
+		for _, idx := range cols {
+			vals = append(vals, df.Series[idx].Value(row, dontLock))
+		}
+
+		k := groupKey(vals)
+		if _, exists := seen[k]; exists {
+			return nil, &RowError{Row: row, Err: errors.New("duplicate key")}
+		}
+		seen[k] = struct{}{}
+		out = append(out, k)
+	}
+
+	return out, nil
+}
+
+func diffKeyVals(df *DataFrame, keys []string, row int) []interface{} {
+	vals := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		idx, _ := df.NameToColumn(k, dontLock)
+		vals = append(vals, df.Series[idx].Value(row, dontLock))
+	}
+	return vals
+}
+
+func diffValueString(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func blankToNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func intPtrVal(i *int) interface{} {
+	if i == nil {
+		return nil
+	}
+	return int64(*i)
+}
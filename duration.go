@@ -0,0 +1,106 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var isoDurationRegex = regexp.MustCompile(`^([-+])?P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// ParseDuration parses a duration string. s can be a valid input to time.ParseDuration (eg. "1h30m")
+// or an ISO 8601 duration (eg. "PT1H30M" or "P1DT12H"). For ISO 8601 durations, a day is assumed to be 24 hours
+// and a week is assumed to be 7 days. Years and months are not supported since they don't have a fixed length.
+//
+// See: https://golang.org/pkg/time/#ParseDuration and https://en.wikipedia.org/wiki/ISO_8601#Durations
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	if !strings.ContainsAny(s, "Pp") {
+		return time.ParseDuration(s)
+	}
+
+	upper := strings.ToUpper(s)
+
+	matches := isoDurationRegex.FindStringSubmatch(upper)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+
+	// At least 1 component is required (and at least 1 time component after a T)
+	if strings.Join(matches[2:], "") == "" || (strings.Contains(upper, "T") && strings.Join(matches[6:], "") == "") {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+
+	if matches[2] != "" || matches[3] != "" {
+		return 0, fmt.Errorf("years and months are not supported: %s", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var d time.Duration
+	for i, m := range matches[4:] {
+		if m == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(strings.Replace(m, ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		d = d + time.Duration(f*float64(units[i]))
+	}
+
+	if matches[1] == "-" {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// FormatISODuration formats d as an ISO 8601 duration (eg. "PT1H30M"). Days are not used since
+// they don't always contain 24 hours.
+//
+// See: https://en.wikipedia.org/wiki/ISO_8601#Durations
+func FormatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var out string
+	if d < 0 {
+		out = "-"
+		d = -d
+	}
+	out = out + "PT"
+
+	if h := d / time.Hour; h > 0 {
+		out = out + strconv.FormatInt(int64(h), 10) + "H"
+		d = d - h*time.Hour
+	}
+
+	if m := d / time.Minute; m > 0 {
+		out = out + strconv.FormatInt(int64(m), 10) + "M"
+		d = d - m*time.Minute
+	}
+
+	if d > 0 {
+		out = out + strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S"
+	}
+
+	return out
+}
+
+// ISODurationValueFormatter is used by SetValueToStringFormatter
+// to display a time.Duration as an ISO 8601 duration.
+func ISODurationValueFormatter(v interface{}) string {
+	if v == nil {
+		return "NaN"
+	}
+
+	return FormatISODuration(v.(time.Duration))
+}
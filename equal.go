@@ -0,0 +1,301 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+	"sort"
+	"time"
+)
+
+// IsEqualApprox compares the values of s1 and s2 using the AbsTol, RelTol, NaNEqual, TimeTolerance and
+// Unordered options. It is intended to be used by Series that implement IsEqual.
+//
+// NOTE: Neither Series is locked. The types and names of the Series are not checked.
+func IsEqualApprox(ctx context.Context, s1, s2 Series, opts IsEqualOptions) (bool, error) {
+
+	n := s1.NRows(dontLock)
+	if n != s2.NRows(dontLock) {
+		return false, nil
+	}
+
+	if !opts.Unordered {
+		for row := 0; row < n; row++ {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+
+			if !valuesEqual(s1, s1.Value(row, dontLock), s2.Value(row, dontLock), opts) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	less1 := func(i, j int) bool { return valueLess(s1, s1.Value(i, dontLock), s1.Value(j, dontLock)) }
+	less2 := func(i, j int) bool { return valueLess(s2, s2.Value(i, dontLock), s2.Value(j, dontLock)) }
+	equal := func(i, j int) bool { return valuesEqual(s1, s1.Value(i, dontLock), s2.Value(j, dontLock), opts) }
+
+	return matchUnordered(ctx, n, less1, less2, equal)
+}
+
+// isEqualUnordered compares the rows of df and df2 ignoring their order.
+// The Series must have already been compared.
+func (df *DataFrame) isEqualUnordered(ctx context.Context, df2 *DataFrame, opts IsEqualOptions) (bool, error) {
+
+	if df.n != df2.n {
+		return false, nil
+	}
+
+	rowLess := func(d *DataFrame) func(i, j int) bool {
+		return func(i, j int) bool {
+			for _, s := range d.Series {
+				a, b := s.Value(i, dontLock), s.Value(j, dontLock)
+				if valueLess(s, a, b) {
+					return true
+				}
+				if valueLess(s, b, a) {
+					return false
+				}
+			}
+			return false
+		}
+	}
+
+	rowsEqual := func(i, j int) bool {
+		for idx, s := range df.Series {
+			if !valuesEqual(s, s.Value(i, dontLock), df2.Series[idx].Value(j, dontLock), opts) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return matchUnordered(ctx, df.n, rowLess(df), rowLess(df2), rowsEqual)
+}
+
+// valueLess returns true if a < b. Nil values are less than all other values.
+func valueLess(s Series, a, b interface{}) bool {
+	if a == nil {
+		return b != nil
+	}
+	if b == nil {
+		return false
+	}
+	return s.IsLessThanFunc(a, b)
+}
+
+// matchUnordered returns true if each of the n items on the left can be paired with a different item on the
+// right such that equal(left, right) is true for every pair.
+//
+// Both sides are sorted and compared pairwise first. If that fails (eg. due to the tolerances or
+// values that can't be ordered), a maximum bipartite matching is used to confirm the result.
+func matchUnordered(ctx context.Context, n int, less1, less2 func(i, j int) bool, equal func(i, j int) bool) (bool, error) {
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if sortedEqual(n, less1, less2, equal) {
+		return true, nil
+	}
+
+	return bipartiteMatch(ctx, n, equal)
+}
+
+// sortedEqual sorts both sides and compares them pairwise.
+func sortedEqual(n int, less1, less2 func(i, j int) bool, equal func(i, j int) bool) (eq bool) {
+
+	defer func() {
+		if x := recover(); x != nil {
+			// Values can't be ordered
+			eq = false
+		}
+	}()
+
+	p1 := identityPermutation(0, n)
+	p2 := identityPermutation(0, n)
+
+	sort.Slice(p1, func(i, j int) bool { return less1(p1[i], p1[j]) })
+	sort.Slice(p2, func(i, j int) bool { return less2(p2[i], p2[j]) })
+
+	for k := 0; k < n; k++ {
+		if !equal(p1[k], p2[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// bipartiteMatch returns true if a perfect matching exists between the n items on the left and right,
+// where an item can only be paired with an item it is equal to (Hopcroft-Karp algorithm).
+func bipartiteMatch(ctx context.Context, n int, equal func(i, j int) bool) (bool, error) {
+
+	adj := make([][]int, n)
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		for j := 0; j < n; j++ {
+			if equal(i, j) {
+				adj[i] = append(adj[i], j)
+			}
+		}
+
+		if len(adj[i]) == 0 {
+			return false, nil
+		}
+	}
+
+	matchL := make([]int, n)
+	matchR := make([]int, n)
+	for i := 0; i < n; i++ {
+		matchL[i] = -1
+		matchR[i] = -1
+	}
+	dist := make([]int, n)
+
+	// bfs builds the layers of alternating paths starting from the unmatched items on the left
+	bfs := func() bool {
+		queue := []int{}
+		for i := range matchL {
+			if matchL[i] == -1 {
+				dist[i] = 0
+				queue = append(queue, i)
+			} else {
+				dist[i] = -1
+			}
+		}
+
+		var found bool
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			for _, j := range adj[i] {
+				k := matchR[j]
+				if k == -1 {
+					found = true
+				} else if dist[k] == -1 {
+					dist[k] = dist[i] + 1
+					queue = append(queue, k)
+				}
+			}
+		}
+		return found
+	}
+
+	// dfs finds an augmenting path from i
+	var dfs func(i int) bool
+	dfs = func(i int) bool {
+		for _, j := range adj[i] {
+			k := matchR[j]
+			if k == -1 || (dist[k] == dist[i]+1 && dfs(k)) {
+				matchL[i] = j
+				matchR[j] = i
+				return true
+			}
+		}
+		dist[i] = -1
+		return false
+	}
+
+	var matched int
+	for bfs() {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		for i := range matchL {
+			if matchL[i] == -1 && dfs(i) {
+				matched++
+			}
+		}
+	}
+
+	return matched == n, nil
+}
+
+// valuesEqual returns true if a and b are equal. float64, complex128 and time.Time values are compared
+// using the tolerances. Other values are compared using the IsEqualFunc of s.
+func valuesEqual(s Series, a, b interface{}, opts IsEqualOptions) bool {
+
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			if math.IsNaN(x) || math.IsNaN(y) {
+				return opts.NaNEqual && math.IsNaN(x) && math.IsNaN(y)
+			}
+			if x == y {
+				return true
+			}
+			return withinTol(math.Abs(x-y), math.Abs(x), math.Abs(y), opts)
+		}
+	case complex128:
+		if y, ok := b.(complex128); ok {
+			if cmplx.IsNaN(x) || cmplx.IsNaN(y) {
+				return opts.NaNEqual && cmplx.IsNaN(x) && cmplx.IsNaN(y)
+			}
+			if x == y {
+				return true
+			}
+			return withinTol(cmplx.Abs(x-y), cmplx.Abs(x), cmplx.Abs(y), opts)
+		}
+	case []interface{}:
+		// Compare the elements of a SeriesList using the child Series
+		if y, ok := b.([]interface{}); ok && len(x) == len(y) {
+			es := s
+			if ls, ok := s.(*SeriesList); ok {
+				es = ls.child
+			}
+			for i := range x {
+				if !valuesEqual(es, x[i], y[i], opts) {
+					return false
+				}
+			}
+			return true
+		}
+	case map[string]interface{}:
+		// Compare the fields of a SeriesStruct using the Series of each field
+		if y, ok := b.(map[string]interface{}); ok && len(x) == len(y) {
+			ss, _ := s.(*SeriesStruct)
+			for k, xv := range x {
+				yv, exists := y[k]
+				if !exists {
+					return false
+				}
+
+				es := s
+				if ss != nil {
+					if f := ss.field(k); f != nil {
+						es = f
+					}
+				}
+				if !valuesEqual(es, xv, yv, opts) {
+					return false
+				}
+			}
+			return true
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			d := x.Sub(y)
+			if d < 0 {
+				d = -d
+			}
+			return d <= opts.TimeTolerance
+		}
+	}
+
+	return s.IsEqualFunc(a, b)
+}
+
+func withinTol(diff, a, b float64, opts IsEqualOptions) bool {
+	return diff <= opts.AbsTol || diff <= opts.RelTol*math.Max(a, b)
+}
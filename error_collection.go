@@ -45,6 +45,13 @@ func (ec *ErrorCollection) IsNil(lock ...bool) bool {
 	return len(ec.errors) == 0
 }
 
+// Errors returns the errors contained in the ErrorCollection.
+func (ec *ErrorCollection) Errors() []error {
+	ec.Lock()
+	defer ec.Unlock()
+	return append([]error{}, ec.errors...)
+}
+
 // Error implements the error interface.
 func (ec *ErrorCollection) Error() string {
 	ec.Lock()
@@ -21,3 +21,19 @@ func (re *RowError) Error() string {
 func (re *RowError) Unwrap() error {
 	return re.Err
 }
+
+// SeriesError signifies that a particular Series contained or generated an error.
+type SeriesError struct {
+	Series string
+	Err    error
+}
+
+// Error implements the error interface.
+func (se *SeriesError) Error() string {
+	return fmt.Sprintf("series: %s: %v", se.Series, se.Err)
+}
+
+// Unwrap implements the Wrapper interface.
+func (se *SeriesError) Unwrap() error {
+	return se.Err
+}
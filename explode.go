@@ -0,0 +1,97 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+)
+
+// ExplodeOptions modifies the behavior of Explode.
+type ExplodeOptions struct {
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Explode returns a new DataFrame with one row for each element of the lists contained in the SeriesList
+// with the given name. The values of the other Series are duplicated for each element. The SeriesList is
+// replaced by a Series of its child type (with the same name). A nil value or an empty list produces a single
+// row containing a nil value.
+//
+// NOTE: All other Series in df must implement the NewSerieser interface.
+//
+// Example:
+//
+//  // | id | tags     |        | id | tags |
+//  // | 1  | [a b]    |   =>   | 1  | a    |
+//  // | 2  | NaN      |        | 1  | b    |
+//  //                          | 2  | NaN  |
+//  exploded, err := dataframe.Explode(ctx, df, "tags")
+//
+func Explode(ctx context.Context, df *DataFrame, col string, opts ...ExplodeOptions) (*DataFrame, error) {
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	idx, err := df.NameToColumn(col, dontLock)
+	if err != nil {
+		return nil, err
+	}
+
+	ls, ok := df.Series[idx].(*SeriesList)
+	if !ok {
+		return nil, &SeriesError{Series: col, Err: errors.New("series must be a SeriesList")}
+	}
+
+	// Count rows of new DataFrame
+	var n int
+	for row := 0; row < df.n; row++ {
+		if l := len(ls.values[row]); l > 0 {
+			n = n + l
+		} else {
+			n++
+		}
+	}
+
+	init := &SeriesInit{Capacity: n}
+
+	seriess := make([]Series, 0, len(df.Series))
+	for i, s := range df.Series {
+		if i == idx {
+			seriess = append(seriess, ls.NewChildSeries(col, init))
+			continue
+		}
+
+		nser, ok := s.(NewSerieser)
+		if !ok {
+			return nil, &SeriesError{Series: s.Name(dontLock), Err: errors.New("series must implement NewSerieser interface")}
+		}
+		seriess = append(seriess, nser.NewSeries(s.Name(dontLock), init))
+	}
+
+	for row := 0; row < df.n; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		list := ls.values[row]
+		if len(list) == 0 {
+			list = []interface{}{nil}
+		}
+
+		for _, elem := range list {
+			for i, s := range df.Series {
+				if i == idx {
+					seriess[i].Append(elem, dontLock)
+				} else {
+					seriess[i].Append(s.Value(row, dontLock), dontLock)
+				}
+			}
+		}
+	}
+
+	return NewDataFrame(seriess...), nil
+}
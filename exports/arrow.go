@@ -0,0 +1,321 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/bitutil"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// ArrowExportOptions contains options for ExportToArrowRecord, ExportToArrowTable,
+// ExportToArrowStream and ExportToArrowFile functions.
+type ArrowExportOptions struct {
+
+	// Range is used to export a subset of rows from the dataframe.
+	Range dataframe.Range
+
+	// Allocator is used to allocate the memory of the Arrow arrays.
+	// It defaults to memory.DefaultAllocator.
+	//
+	// See: https://godoc.org/github.com/apache/arrow/go/arrow/memory#Allocator
+	Allocator memory.Allocator
+
+	// ZeroCopy can be set to share the underlying memory of a SeriesFloat64 with the Arrow array
+	// instead of copying it. Only a validity bitmap is allocated.
+	// Other Series types do not have a compatible memory layout and are always copied.
+	//
+	// NOTE: Modifying the Series will also modify the Arrow array.
+	ZeroCopy bool
+}
+
+var (
+	minArrowTimestamp = time.Unix(0, math.MinInt64)
+	maxArrowTimestamp = time.Unix(0, math.MaxInt64)
+
+	errTimestampRange = errors.New("time can't be represented as a timestamp[ns]")
+)
+
+// ExportToArrowRecord exports a Dataframe as an Arrow Record. The Record must be released by the caller.
+//
+// Series are stored in the following Arrow types:
+//
+//  SeriesFloat64: float64
+//  SeriesInt64: int64
+//  SeriesString: utf8
+//  SeriesTime: timestamp[ns] (with the time zone set to the Location's name, or "UTC")
+//  SeriesDuration: duration[ns]
+//  other: utf8 (using ValueString)
+//
+// Locations that can't be loaded by name (eg. created using time.FixedZone) are stored as an offset (eg. "+05:30").
+// Nil values are stored as nulls. An error is returned if a time is outside the range of
+// timestamp[ns] (approximately the years 1678 to 2262).
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html
+func ExportToArrowRecord(ctx context.Context, df *dataframe.DataFrame, options ...ArrowExportOptions) (array.Record, error) {
+
+	df.Lock()
+	defer df.Unlock()
+
+	return exportToArrowRecord(ctx, df, options...)
+}
+
+// ExportToArrowTable exports a Dataframe as an Arrow Table (with 1 chunk per column).
+// The Table must be released by the caller.
+//
+// See: ExportToArrowRecord
+func ExportToArrowTable(ctx context.Context, df *dataframe.DataFrame, options ...ArrowExportOptions) (array.Table, error) {
+
+	df.Lock()
+	defer df.Unlock()
+
+	rec, err := exportToArrowRecord(ctx, df, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer rec.Release()
+
+	return array.NewTableFromRecords(rec.Schema(), []array.Record{rec}), nil
+}
+
+// ExportToArrowStream exports a Dataframe using the Arrow IPC streaming format.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#ipc-streaming-format
+func ExportToArrowStream(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...ArrowExportOptions) error {
+
+	df.Lock()
+	defer df.Unlock()
+
+	rec, err := exportToArrowRecord(ctx, df, options...)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	aw := ipc.NewWriter(w, ipc.WithSchema(rec.Schema()), ipc.WithAllocator(arrowAllocator(options...)))
+	if err := aw.Write(rec); err != nil {
+		aw.Close()
+		return err
+	}
+
+	return aw.Close()
+}
+
+// ExportToArrowFile exports a Dataframe using the Arrow IPC file format (also known as Feather V2).
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#ipc-file-format
+func ExportToArrowFile(ctx context.Context, w io.WriteSeeker, df *dataframe.DataFrame, options ...ArrowExportOptions) error {
+
+	df.Lock()
+	defer df.Unlock()
+
+	rec, err := exportToArrowRecord(ctx, df, options...)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	aw, err := ipc.NewFileWriter(w, ipc.WithSchema(rec.Schema()), ipc.WithAllocator(arrowAllocator(options...)))
+	if err != nil {
+		return err
+	}
+
+	if err := aw.Write(rec); err != nil {
+		aw.Close()
+		return err
+	}
+
+	return aw.Close()
+}
+
+func arrowAllocator(options ...ArrowExportOptions) memory.Allocator {
+	if len(options) > 0 && options[0].Allocator != nil {
+		return options[0].Allocator
+	}
+	return memory.DefaultAllocator
+}
+
+func exportToArrowRecord(ctx context.Context, df *dataframe.DataFrame, options ...ArrowExportOptions) (array.Record, error) {
+
+	var (
+		r        dataframe.Range
+		zeroCopy bool
+	)
+
+	if len(options) > 0 {
+		r = options[0].Range
+		zeroCopy = options[0].ZeroCopy
+	}
+	mem := arrowAllocator(options...)
+
+	s, e := 0, -1 // No rows
+
+	nRows := df.NRows(dataframe.DontLock)
+	if nRows > 0 {
+		var err error
+		s, e, err = r.Limits(nRows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]arrow.Field, 0, len(df.Series))
+	cols := make([]array.Interface, 0, len(df.Series))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for _, aSeries := range df.Series {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var col array.Interface
+
+		switch se := aSeries.(type) {
+		case *dataframe.SeriesFloat64:
+			if zeroCopy {
+				col = zeroCopyFloat64(mem, se.Values[s:e+1])
+			} else {
+				b := array.NewFloat64Builder(mem)
+				b.Reserve(e - s + 1)
+				for _, v := range se.Values[s : e+1] {
+					if math.IsNaN(v) {
+						b.AppendNull()
+					} else {
+						b.Append(v)
+					}
+				}
+				col = b.NewArray()
+				b.Release()
+			}
+		case *dataframe.SeriesInt64:
+			b := array.NewInt64Builder(mem)
+			b.Reserve(e - s + 1)
+			for row := s; row <= e; row++ {
+				if v := se.Value(row, dataframe.DontLock); v != nil {
+					b.Append(v.(int64))
+				} else {
+					b.AppendNull()
+				}
+			}
+			col = b.NewArray()
+			b.Release()
+		case *dataframe.SeriesTime:
+			tz := "UTC"
+			if se.Location != nil {
+				tz = arrowTimeZone(se.Location)
+			}
+
+			b := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: tz})
+			b.Reserve(e - s + 1)
+			for idx, v := range se.Values[s : e+1] {
+				if v != nil {
+					// UnixNano overflows outside the years 1678 to 2262
+					if v.Before(minArrowTimestamp) || v.After(maxArrowTimestamp) {
+						b.Release()
+						return nil, &dataframe.RowError{Row: s + idx, Err: &dataframe.SeriesError{Series: se.Name(dataframe.DontLock), Err: errTimestampRange}}
+					}
+					b.Append(arrow.Timestamp(v.UnixNano()))
+				} else {
+					b.AppendNull()
+				}
+			}
+			col = b.NewArray()
+			b.Release()
+		case *dataframe.SeriesDuration:
+			b := array.NewDurationBuilder(mem, arrow.FixedWidthTypes.Duration_ns.(*arrow.DurationType))
+			b.Reserve(e - s + 1)
+			for row := s; row <= e; row++ {
+				if v := se.Value(row, dataframe.DontLock); v != nil {
+					b.Append(arrow.Duration(v.(time.Duration)))
+				} else {
+					b.AppendNull()
+				}
+			}
+			col = b.NewArray()
+			b.Release()
+		default: // SeriesString and other Series
+			b := array.NewStringBuilder(mem)
+			b.Reserve(e - s + 1)
+			for row := s; row <= e; row++ {
+				if aSeries.Value(row, dataframe.DontLock) != nil {
+					b.Append(aSeries.ValueString(row, dataframe.DontLock))
+				} else {
+					b.AppendNull()
+				}
+			}
+			col = b.NewArray()
+			b.Release()
+		}
+
+		cols = append(cols, col)
+		fields = append(fields, arrow.Field{Name: aSeries.Name(dataframe.DontLock), Type: col.DataType(), Nullable: true})
+	}
+
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(e-s+1)), nil
+}
+
+// arrowTimeZone returns the Arrow time zone of loc. Locations that can't be loaded by name
+// are stored as a fixed offset in the format "+hh:mm" or "-hh:mm".
+func arrowTimeZone(loc *time.Location) string {
+	if _, err := time.LoadLocation(loc.String()); err == nil {
+		return loc.String()
+	}
+
+	_, offset := time.Unix(0, 0).In(loc).Zone()
+
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// zeroCopyFloat64 creates an Arrow array that shares vals. NaN values are marked as null.
+func zeroCopyFloat64(mem memory.Allocator, vals []float64) array.Interface {
+
+	var (
+		nulls    int
+		validity *memory.Buffer
+	)
+
+	for i, v := range vals {
+		if !math.IsNaN(v) {
+			continue
+		}
+
+		if validity == nil {
+			validity = memory.NewResizableBuffer(mem)
+			validity.Resize(int(bitutil.BytesForBits(int64(len(vals)))))
+			defer validity.Release()
+
+			bs := validity.Bytes()
+			for j := range bs {
+				bs[j] = 0xFF
+			}
+		}
+		bitutil.ClearBit(validity.Bytes(), i)
+		nulls++
+	}
+
+	data := array.NewData(arrow.PrimitiveTypes.Float64, len(vals), []*memory.Buffer{validity, memory.NewBufferBytes(arrow.Float64Traits.CastToBytes(vals))}, nil, nulls, 0)
+	defer data.Release()
+
+	return array.MakeFromData(data)
+}
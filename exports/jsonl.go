@@ -16,6 +16,7 @@ type JSONExportOptions struct {
 	// NullString is used to set what nil values should be encoded to.
 	// Common options are strings: NULL, \N, NaN, NA.
 	// If not set, then null (non-string) is used.
+	// It also applies to the nil values within a SeriesList or SeriesStruct.
 	NullString *string
 
 	// Range is used to export a subset of rows from the Dataframe.
@@ -73,8 +74,8 @@ func ExportToJSON(ctx context.Context, w io.Writer, df *dataframe.DataFrame, opt
 
 				val := aSeries.Value(row)
 
-				if val == nil && null != nil {
-					record[fieldName] = null
+				if null != nil {
+					record[fieldName] = jsonNull(val, null)
 				} else {
 					record[fieldName] = val
 				}
@@ -89,3 +90,25 @@ func ExportToJSON(ctx context.Context, w io.Writer, df *dataframe.DataFrame, opt
 
 	return nil
 }
+
+// jsonNull replaces the nil values within val with null (recursively).
+func jsonNull(val interface{}, null *string) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return null
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			out[k] = jsonNull(x, null)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, x := range v {
+			out = append(out, jsonNull(x, null))
+		}
+		return out
+	default:
+		return val
+	}
+}
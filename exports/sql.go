@@ -239,6 +239,12 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 				switch v := val.(type) {
 				case time.Time:
 					ival = &[]string{v.Format("2006-01-02 15:04:05")}[0]
+				case time.Duration:
+					if database == MySQL {
+						ival = &[]string{mysqlTime(v)}[0]
+					} else {
+						ival = &[]string{dataframe.FormatISODuration(v)}[0]
+					}
 				default:
 					ival = &[]string{series.ValueString(row, dataframe.DontLock)}[0]
 				}
@@ -310,3 +316,26 @@ func escapeNames(database Database, names []string) []string {
 
 	return out
 }
+
+// mysqlTime formats d for a MySQL TIME column (eg. "-01:30:00.5").
+// PostgreSQL INTERVAL columns accept ISO 8601 durations instead.
+//
+// See: https://dev.mysql.com/doc/refman/8.0/en/time.html
+func mysqlTime(d time.Duration) string {
+	var sign string
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	sec := (d % time.Minute) / time.Second
+	micro := (d % time.Second) / time.Microsecond
+
+	out := fmt.Sprintf("%s%02d:%02d:%02d", sign, h, m, sec)
+	if micro > 0 {
+		out = out + strings.TrimRight(fmt.Sprintf(".%06d", micro), "0")
+	}
+	return out
+}
@@ -0,0 +1,401 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+var (
+	errIntRange      = errors.New("value can't be represented as an int64")
+	errDurationRange = errors.New("duration can't be represented as a time.Duration")
+)
+
+// ArrowLoadOptions is likely to change.
+type ArrowLoadOptions struct {
+
+	// Allocator is used to allocate memory when reading the Arrow IPC formats.
+	// It defaults to memory.DefaultAllocator.
+	//
+	// See: https://godoc.org/github.com/apache/arrow/go/arrow/memory#Allocator
+	Allocator memory.Allocator
+
+	// ZeroCopy can be set to share the underlying memory of a float64 Arrow array (that contains no nulls or NaN values)
+	// with the SeriesFloat64 instead of copying it. It only applies to LoadFromArrowRecord and LoadFromArrowTable
+	// (when the column consists of a single chunk).
+	//
+	// NOTE: The Record or Table must remain valid for as long as the Series is used.
+	// Modifying the Series will also modify the Arrow array.
+	ZeroCopy bool
+
+	// Location is used to interpret timestamps that don't contain time zone information.
+	// It is also set as the Location of the SeriesTime created for them. When nil, UTC is assumed.
+	Location *time.Location
+}
+
+// LoadFromArrowRecord will load data from an Arrow Record.
+//
+// Arrow types are stored in the following Series:
+//
+//  float16, float32, float64: SeriesFloat64
+//  int8 ... int64, uint8 ... uint64: SeriesInt64
+//  bool: SeriesInt64 (with BoolValueFormatter)
+//  utf8, binary: SeriesString
+//  timestamp, date32, date64: SeriesTime
+//  duration: SeriesDuration
+//
+// Nulls are stored as nil values.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html
+func LoadFromArrowRecord(ctx context.Context, rec array.Record, opts ...ArrowLoadOptions) (*dataframe.DataFrame, error) {
+
+	var options ArrowLoadOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	seriess := make([]dataframe.Series, 0, rec.NumCols())
+	for i, col := range rec.Columns() {
+		s, err := loadArrowColumn(ctx, rec.Schema().Field(i), []array.Interface{col}, options)
+		if err != nil {
+			return nil, err
+		}
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// LoadFromArrowTable will load data from an Arrow Table.
+//
+// See: LoadFromArrowRecord
+func LoadFromArrowTable(ctx context.Context, tbl array.Table, opts ...ArrowLoadOptions) (*dataframe.DataFrame, error) {
+
+	var options ArrowLoadOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	seriess := make([]dataframe.Series, 0, tbl.NumCols())
+	for i := 0; i < int(tbl.NumCols()); i++ {
+		col := tbl.Column(i)
+		s, err := loadArrowColumn(ctx, col.Field(), col.Data().Chunks(), options)
+		if err != nil {
+			return nil, err
+		}
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// LoadFromArrowStream will load data encoded using the Arrow IPC streaming format.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#ipc-streaming-format
+func LoadFromArrowStream(ctx context.Context, r io.Reader, opts ...ArrowLoadOptions) (*dataframe.DataFrame, error) {
+
+	var options ArrowLoadOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	ar, err := ipc.NewReader(r, ipc.WithAllocator(arrowAllocator(options)))
+	if err != nil {
+		return nil, err
+	}
+	defer ar.Release()
+
+	recs := []array.Record{}
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+
+	for ar.Next() {
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rec := ar.Record()
+		rec.Retain()
+		recs = append(recs, rec)
+	}
+	if err := ar.Err(); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return loadFromArrowRecords(ctx, ar.Schema(), recs, options)
+}
+
+// LoadFromArrowFile will load data encoded using the Arrow IPC file format (also known as Feather V2).
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#ipc-file-format
+func LoadFromArrowFile(ctx context.Context, r ipc.ReadAtSeeker, opts ...ArrowLoadOptions) (*dataframe.DataFrame, error) {
+
+	var options ArrowLoadOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	ar, err := ipc.NewFileReader(r, ipc.WithAllocator(arrowAllocator(options)))
+	if err != nil {
+		return nil, err
+	}
+	defer ar.Close()
+
+	recs := []array.Record{}
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+
+	for i := 0; i < ar.NumRecords(); i++ {
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rec, err := ar.Record(i)
+		if err != nil {
+			return nil, err
+		}
+		rec.Retain()
+		recs = append(recs, rec)
+	}
+
+	return loadFromArrowRecords(ctx, ar.Schema(), recs, options)
+}
+
+func arrowAllocator(options ArrowLoadOptions) memory.Allocator {
+	if options.Allocator != nil {
+		return options.Allocator
+	}
+	return memory.DefaultAllocator
+}
+
+// loadFromArrowRecords loads data from records read using the Arrow IPC formats.
+// The records are released after they are read, so ZeroCopy is not possible.
+func loadFromArrowRecords(ctx context.Context, schema *arrow.Schema, recs []array.Record, options ArrowLoadOptions) (*dataframe.DataFrame, error) {
+
+	options.ZeroCopy = false
+
+	seriess := make([]dataframe.Series, 0, len(schema.Fields()))
+	for i, field := range schema.Fields() {
+		chunks := make([]array.Interface, 0, len(recs))
+		for _, rec := range recs {
+			chunks = append(chunks, rec.Column(i))
+		}
+
+		s, err := loadArrowColumn(ctx, field, chunks, options)
+		if err != nil {
+			return nil, err
+		}
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// loadArrowColumn creates a Series from the chunks of an Arrow column.
+func loadArrowColumn(ctx context.Context, field arrow.Field, chunks []array.Interface, options ArrowLoadOptions) (dataframe.Series, error) {
+
+	var n int
+	for _, chunk := range chunks {
+		n = n + chunk.Len()
+	}
+	init := &dataframe.SeriesInit{Capacity: n}
+
+	var (
+		s   dataframe.Series
+		loc *time.Location // Used to interpret timestamps
+	)
+
+	switch dt := field.Type.(type) {
+	case *arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type:
+		if _, ok := dt.(*arrow.Float64Type); ok && options.ZeroCopy && len(chunks) == 1 && chunks[0].NullN() == 0 {
+			vals := chunks[0].(*array.Float64).Float64Values()
+			if !containsNaN(vals) {
+				sf := dataframe.NewSeriesFloat64(field.Name, nil)
+				sf.Values = vals[:len(vals):len(vals)] // Appending must not overwrite memory beyond the array
+				return sf, nil
+			}
+		}
+		s = dataframe.NewSeriesFloat64(field.Name, init)
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		s = dataframe.NewSeriesInt64(field.Name, init)
+	case *arrow.BooleanType:
+		s = dataframe.NewSeriesInt64(field.Name, init)
+		s.SetValueToStringFormatter(dataframe.BoolValueFormatter)
+	case *arrow.StringType, *arrow.BinaryType:
+		s = dataframe.NewSeriesString(field.Name, init)
+	case *arrow.TimestampType:
+		st := dataframe.NewSeriesTime(field.Name, init)
+		if dt.TimeZone == "" {
+			st.Location = options.Location
+		} else {
+			l, err := arrowLocation(dt.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("series: %s: %w", field.Name, err)
+			}
+			st.Location = l
+		}
+		loc = st.Location
+		s = st
+	case *arrow.Date32Type, *arrow.Date64Type:
+		s = dataframe.NewSeriesTime(field.Name, init)
+	case *arrow.DurationType:
+		s = dataframe.NewSeriesDuration(field.Name, init)
+	default:
+		return nil, fmt.Errorf("series: %s: unsupported arrow type: %s", field.Name, field.Type)
+	}
+
+	var row int
+	for _, chunk := range chunks {
+		for i := 0; i < chunk.Len(); i, row = i+1, row+1 {
+
+			// Cancel operation
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			if chunk.IsNull(i) {
+				s.Append(nil, dataframe.DontLock)
+				continue
+			}
+
+			var val interface{}
+
+			switch arr := chunk.(type) {
+			case *array.Float16:
+				val = float64(arr.Value(i).Float32())
+			case *array.Float32:
+				val = float64(arr.Value(i))
+			case *array.Float64:
+				val = arr.Value(i)
+			case *array.Int8:
+				val = int64(arr.Value(i))
+			case *array.Int16:
+				val = int64(arr.Value(i))
+			case *array.Int32:
+				val = int64(arr.Value(i))
+			case *array.Int64:
+				val = arr.Value(i)
+			case *array.Uint8:
+				val = int64(arr.Value(i))
+			case *array.Uint16:
+				val = int64(arr.Value(i))
+			case *array.Uint32:
+				val = int64(arr.Value(i))
+			case *array.Uint64:
+				if arr.Value(i) > math.MaxInt64 {
+					return nil, &dataframe.RowError{Row: row, Err: &dataframe.SeriesError{Series: field.Name, Err: errIntRange}}
+				}
+				val = int64(arr.Value(i))
+			case *array.Boolean:
+				val = int64(dataframe.B(arr.Value(i)))
+			case *array.String:
+				val = arr.Value(i)
+			case *array.Binary:
+				val = arr.ValueString(i)
+			case *array.Timestamp:
+				unit := arr.DataType().(*arrow.TimestampType).Unit
+				t := unixTime(int64(arr.Value(i)), unit).UTC()
+				if loc != nil {
+					if arr.DataType().(*arrow.TimestampType).TimeZone == "" {
+						// Wall clock time
+						t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+					} else {
+						t = t.In(loc)
+					}
+				}
+				val = t
+			case *array.Date32:
+				val = time.Unix(int64(arr.Value(i))*86400, 0).UTC()
+			case *array.Date64:
+				val = unixTime(int64(arr.Value(i)), arrow.Millisecond).UTC()
+			case *array.Duration:
+				m := timeUnitMultiplier(arr.DataType().(*arrow.DurationType).Unit)
+				v := int64(arr.Value(i))
+				if v > math.MaxInt64/int64(m) || v < math.MinInt64/int64(m) {
+					return nil, &dataframe.RowError{Row: row, Err: &dataframe.SeriesError{Series: field.Name, Err: errDurationRange}}
+				}
+				val = time.Duration(v) * m
+			}
+
+			s.Append(val, dataframe.DontLock)
+		}
+	}
+
+	return s, nil
+}
+
+// containsNaN returns true if vals contains a NaN value (which a SeriesFloat64 treats as nil).
+func containsNaN(vals []float64) bool {
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeUnitMultiplier returns the duration of one unit of an Arrow time unit.
+func timeUnitMultiplier(unit arrow.TimeUnit) time.Duration {
+	switch unit {
+	case arrow.Second:
+		return time.Second
+	case arrow.Millisecond:
+		return time.Millisecond
+	case arrow.Microsecond:
+		return time.Microsecond
+	}
+	return time.Nanosecond
+}
+
+// unixTime returns the time of an Arrow timestamp (or date64) measured in unit since the Unix epoch.
+func unixTime(v int64, unit arrow.TimeUnit) time.Time {
+	perSec := int64(time.Second / timeUnitMultiplier(unit))
+
+	sec, frac := v/perSec, v%perSec
+	if frac < 0 {
+		sec--
+		frac = frac + perSec
+	}
+	return time.Unix(sec, frac*int64(timeUnitMultiplier(unit)))
+}
+
+// arrowLocation returns the Location of an Arrow time zone. The time zone can be the name of a
+// location or a fixed offset in the format "+hh:mm" or "-hh:mm".
+func arrowLocation(tz string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tz)
+	if err == nil {
+		return loc, nil
+	}
+
+	if len(tz) == 6 && (tz[0] == '+' || tz[0] == '-') && tz[3] == ':' {
+		if t, pErr := time.Parse("15:04", tz[1:]); pErr == nil {
+			offset := t.Hour()*3600 + t.Minute()*60
+			if tz[0] == '-' {
+				offset = -offset
+			}
+			return time.FixedZone(tz, offset), nil
+		}
+	}
+	return nil, err
+}
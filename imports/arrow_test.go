@@ -0,0 +1,282 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+func arrowTestDataFrame() *dataframe.DataFrame {
+	t1 := time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)
+
+	st := dataframe.NewSeriesTime("time", nil, t1, nil, t1.Add(time.Hour))
+	st.Location = time.UTC
+
+	return dataframe.NewDataFrame(
+		dataframe.NewSeriesFloat64("float", nil, 1.5, nil, 3.5),
+		dataframe.NewSeriesInt64("int", nil, 1, 2, nil),
+		dataframe.NewSeriesString("string", nil, nil, "b", "c"),
+		st,
+		dataframe.NewSeriesDuration("duration", nil, time.Second, nil, time.Minute),
+	)
+}
+
+func TestArrow(t *testing.T) {
+	ctx := context.Background()
+
+	df := arrowTestDataFrame()
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// Record
+	rec, err := exports.ExportToArrowRecord(ctx, df, exports.ArrowExportOptions{Allocator: mem})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	if rec.NumRows() != 3 || rec.NumCols() != 5 || rec.Column(0).NullN() != 1 {
+		t.Errorf("wrong record: %v", rec)
+	}
+
+	got, err := LoadFromArrowRecord(ctx, rec)
+	rec.Release()
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+	assertEqualDS(t, df, got)
+
+	// Table
+	tbl, err := exports.ExportToArrowTable(ctx, df, exports.ArrowExportOptions{Allocator: mem, Range: dataframe.RangeFinite(1)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	got, err = LoadFromArrowTable(ctx, tbl)
+	tbl.Release()
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	expected := df.Copy(dataframe.RangeFinite(1))
+	assertEqualDS(t, expected, got)
+
+	// Stream
+	var buf bytes.Buffer
+	if err := exports.ExportToArrowStream(ctx, &buf, df, exports.ArrowExportOptions{Allocator: mem}); err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	got, err = LoadFromArrowStream(ctx, &buf)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+	assertEqualDS(t, df, got)
+
+	// File
+	f, err := ioutil.TempFile("", "*.arrow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := exports.ExportToArrowFile(ctx, f, df, exports.ArrowExportOptions{Allocator: mem}); err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	got, err = LoadFromArrowFile(ctx, f)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+	assertEqualDS(t, df, got)
+}
+
+func TestArrowZeroCopy(t *testing.T) {
+	ctx := context.Background()
+
+	s := dataframe.NewSeriesFloat64("float", nil, 1.0, 2.0, 3.0)
+	df := dataframe.NewDataFrame(s)
+
+	rec, err := exports.ExportToArrowRecord(ctx, df, exports.ArrowExportOptions{ZeroCopy: true})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+	defer rec.Release()
+
+	got, err := LoadFromArrowRecord(ctx, rec, ArrowLoadOptions{ZeroCopy: true})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	// Memory is shared
+	s.Update(1, 5.0)
+	if v := got.Series[0].Value(1); v != 5.0 {
+		t.Errorf("wrong val: expected: %v got: %v", 5.0, v)
+	}
+
+	// Appending does not overwrite shared memory
+	got.Series[0].Append(6.0)
+	if len(s.Values) != 3 || got.Series[0].NRows() != 4 {
+		t.Errorf("wrong rows: expected: %v got: %v", 4, got.Series[0].NRows())
+	}
+
+	// Nils are marked as null
+	s.Update(0, math.NaN())
+	rec2, err := exports.ExportToArrowRecord(ctx, df, exports.ArrowExportOptions{ZeroCopy: true})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+	defer rec2.Release()
+
+	if rec2.Column(0).NullN() != 1 || !rec2.Column(0).IsNull(0) {
+		t.Errorf("wrong nulls: expected: %v got: %v", 1, rec2.Column(0).NullN())
+	}
+}
+
+func TestArrowNaN(t *testing.T) {
+	ctx := context.Background()
+
+	b := array.NewFloat64Builder(memory.DefaultAllocator)
+	b.AppendValues([]float64{1.0, math.NaN(), 3.0}, nil)
+	col := b.NewArray()
+	b.Release()
+	defer col.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "float", Type: arrow.PrimitiveTypes.Float64}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{col}, int64(col.Len()))
+	defer rec.Release()
+
+	// NaN values are counted as nil with or without ZeroCopy
+	for _, zeroCopy := range []bool{false, true} {
+		got, err := LoadFromArrowRecord(ctx, rec, ArrowLoadOptions{ZeroCopy: zeroCopy})
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+		}
+
+		if n, _ := got.Series[0].NilCount(); n != 1 {
+			t.Errorf("wrong nil count: %v: expected: %v got: %v", zeroCopy, 1, n)
+		}
+	}
+}
+
+func TestArrowTimestampRange(t *testing.T) {
+	ctx := context.Background()
+
+	df := dataframe.NewDataFrame(dataframe.NewSeriesTime("time", nil, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	if _, err := exports.ExportToArrowRecord(ctx, df); err == nil {
+		t.Errorf("wrong err: expected: %v got: %v", "error", err)
+	}
+}
+
+func TestArrowTimeUnits(t *testing.T) {
+	ctx := context.Background()
+
+	mem := memory.DefaultAllocator
+
+	// Times outside the range of int64 nanoseconds
+	t1 := time.Date(3000, 1, 2, 3, 4, 5, 0, time.UTC)
+	t2 := time.Date(1500, 6, 7, 8, 9, 10, 500*int(time.Millisecond), time.UTC)
+
+	tb := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Second})
+	tb.Append(arrow.Timestamp(t1.Unix()))
+	ts := tb.NewArray()
+	tb.Release()
+	defer ts.Release()
+
+	db := array.NewDate64Builder(mem)
+	db.Append(arrow.Date64(t2.Unix()*1000 + 500))
+	ds := db.NewArray()
+	db.Release()
+	defer ds.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "timestamp", Type: ts.DataType()}, {Name: "date64", Type: ds.DataType()}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{ts, ds}, 1)
+	defer rec.Release()
+
+	got, err := LoadFromArrowRecord(ctx, rec)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	if v := got.Series[0].Value(0).(time.Time); !v.Equal(t1) {
+		t.Errorf("wrong val: expected: %v got: %v", t1, v)
+	}
+	if v := got.Series[1].Value(0).(time.Time); !v.Equal(t2) {
+		t.Errorf("wrong val: expected: %v got: %v", t2, v)
+	}
+}
+
+func TestArrowRangeErrors(t *testing.T) {
+	ctx := context.Background()
+
+	mem := memory.DefaultAllocator
+
+	ub := array.NewUint64Builder(mem)
+	ub.AppendValues([]uint64{1, math.MaxInt64 + 1}, nil)
+	us := ub.NewArray()
+	ub.Release()
+	defer us.Release()
+
+	db := array.NewDurationBuilder(mem, &arrow.DurationType{Unit: arrow.Second})
+	db.AppendValues([]arrow.Duration{1, math.MaxInt64 / 10}, nil)
+	ds := db.NewArray()
+	db.Release()
+	defer ds.Release()
+
+	for _, col := range []array.Interface{us, ds} {
+		schema := arrow.NewSchema([]arrow.Field{{Name: "col", Type: col.DataType()}}, nil)
+		rec := array.NewRecord(schema, []array.Interface{col}, int64(col.Len()))
+
+		_, err := LoadFromArrowRecord(ctx, rec)
+		rec.Release()
+
+		rowErr, ok := err.(*dataframe.RowError)
+		if !ok || rowErr.Row != 1 {
+			t.Errorf("wrong err: %s: expected: %v got: %v", col.DataType(), "RowError for row 1", err)
+		}
+	}
+}
+
+func TestArrowFixedZone(t *testing.T) {
+	ctx := context.Background()
+
+	loc := time.FixedZone("AEST", 10*60*60)
+	t1 := time.Date(2021, 3, 4, 5, 6, 7, 8, loc)
+
+	st := dataframe.NewSeriesTime("time", nil, t1)
+	st.Location = loc
+
+	rec, err := exports.ExportToArrowRecord(ctx, dataframe.NewDataFrame(st))
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+	defer rec.Release()
+
+	if tz := rec.Schema().Field(0).Type.(*arrow.TimestampType).TimeZone; tz != "+10:00" {
+		t.Errorf("wrong time zone: expected: %v got: %v", "+10:00", tz)
+	}
+
+	got, err := LoadFromArrowRecord(ctx, rec)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+	}
+
+	v := got.Series[0].Value(0).(time.Time)
+	if _, offset := v.Zone(); !v.Equal(t1) || offset != 10*60*60 {
+		t.Errorf("wrong val: expected: %v got: %v", t1, v)
+	}
+}
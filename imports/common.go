@@ -4,6 +4,12 @@
 // It provides inverse functionality to the exports package.
 package imports
 
+import (
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
 // GenericDataConverter is used to convert input data into a generic data type.
 // This is required when importing data for a Generic Series ("SeriesGeneric").
 type GenericDataConverter func(in interface{}) (interface{}, error)
@@ -56,3 +62,40 @@ func parseObject(v map[string]interface{}, prefix string) map[string]interface{}
 
 	return out
 }
+
+// parseTime parses value using layout. Times without time zone information are interpreted as being in loc.
+// When loc is nil, UTC is assumed.
+func parseTime(layout, value string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		return time.Parse(layout, value)
+	}
+
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// setLocation sets the Location of every SeriesTime to loc.
+func setLocation(seriess []dataframe.Series, loc *time.Location) {
+	if loc == nil {
+		return
+	}
+
+	for _, s := range seriess {
+		if ts, ok := s.(*dataframe.SeriesTime); ok {
+			ts.Location = loc
+		}
+	}
+}
+
+// contains returns true if s is in list.
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
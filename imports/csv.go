@@ -67,10 +67,46 @@ type CSVLoadOptions struct {
 	// Headers must be set if the CSV file does not contain a header row. This must be nil if the CSV file contains a
 	// header row.
 	Headers []string
+
+	// Location is used to interpret times that don't contain time zone information.
+	// It is also set as the Location of every SeriesTime created. When nil, UTC is assumed.
+	Location *time.Location
 }
 
 // LoadFromCSV will load data from a csv file.
 func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions) (*dataframe.DataFrame, error) {
+	df, _, err := loadFromCSV(ctx, r, nil, options...)
+	return df, err
+}
+
+// ScanCSV returns a LazyFrame that loads data from a csv file when Collect is called.
+// Only the Series and rows required by the LazyFrame are loaded.
+//
+// NOTE: When InferDataTypes is set, filters that use Series whose data type is not dictated
+// are applied after loading.
+func ScanCSV(r io.ReadSeeker, options ...CSVLoadOptions) *dataframe.LazyFrame {
+	return dataframe.NewLazyFrame(&csvSource{r: r, options: options})
+}
+
+type csvSource struct {
+	r       io.ReadSeeker
+	options []CSVLoadOptions
+}
+
+func (s *csvSource) String() string {
+	return "CSV"
+}
+
+func (s *csvSource) Scan(ctx context.Context, opts dataframe.ScanOptions) (*dataframe.DataFrame, bool, error) {
+	// The LazyFrame may be collected more than once
+	if _, err := s.r.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	return loadFromCSV(ctx, s.r, &opts, s.options...)
+}
+
+// loadFromCSV will load data from a csv file. When scan is provided, only the required Series and rows are loaded.
+func loadFromCSV(ctx context.Context, r io.ReadSeeker, scan *dataframe.ScanOptions, options ...CSVLoadOptions) (*dataframe.DataFrame, bool, error) {
 
 	var init *dataframe.SeriesInit
 
@@ -108,7 +144,7 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 			init = &dataframe.SeriesInit{}
 			for {
 				if err := ctx.Err(); err != nil {
-					return nil, err
+					return nil, false, err
 				}
 
 				_, err := cr.Read()
@@ -117,7 +153,7 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 						newR.Seek(0, io.SeekStart)
 						break
 					}
-					return nil, err
+					return nil, false, err
 				}
 				init.Capacity++
 			}
@@ -130,9 +166,15 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 	var row int
 	var df *dataframe.DataFrame
 
+	var (
+		headers     []string
+		keep        []bool // Series that are required
+		applyFilter bool
+	)
+
 	for {
 		if err := ctx.Err(); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		rec, err := cr.Read()
@@ -140,16 +182,41 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 			if err == io.EOF {
 				break
 			}
-			return nil, err
+			return nil, false, err
 		}
 
 		if row == 0 {
 			// First row contains headings
+			headers = append([]string{}, rec...)
+
+			keep = make([]bool, len(rec))
+			for idx, name := range rec {
+				keep[idx] = scan == nil || scan.Columns == nil || contains(scan.Columns, name)
+			}
+
+			if scan != nil && scan.Filter != nil {
+				applyFilter = true
+
+				// Inferred values are strings until loading is complete
+				if len(options) > 0 && options[0].InferDataTypes {
+					for idx, name := range rec {
+						if !keep[idx] || (scan.FilterColumns != nil && !contains(scan.FilterColumns, name)) {
+							continue
+						}
+						if _, exists := options[0].DictateDataType[name]; !exists {
+							applyFilter = false
+						}
+					}
+				}
+			}
 
 			seriess := []dataframe.Series{}
 
 			// Create the series
-			for _, name := range rec {
+			for idx, name := range rec {
+				if !keep[idx] {
+					continue
+				}
 
 				// Check if the datatype is dictated
 				if len(options) > 0 && len(options[0].DictateDataType) > 0 {
@@ -167,6 +234,8 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 						seriess = append(seriess, dataframe.NewSeriesString(name, init))
 					case time.Time:
 						seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+					case time.Duration:
+						seriess = append(seriess, dataframe.NewSeriesDuration(name, init))
 					case dataframe.NewSerieser:
 						seriess = append(seriess, T.NewSeries(name, init))
 					case Converter:
@@ -190,7 +259,7 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 					if init != nil {
 						knownSize = &init.Capacity
 					}
-					is := newInferSeries(name, knownSize)
+					is := newInferSeries(name, knownSize, options[0].Location)
 					seriess = append(seriess, is)
 				} else {
 					// Default assumption is string
@@ -199,11 +268,17 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 			}
 
 			// Create the dataframe
+			if len(options) > 0 {
+				setLocation(seriess, options[0].Location)
+			}
 			df = dataframe.NewDataFrame(seriess...)
 		} else {
 
 			insertVals := []interface{}{}
 			for idx, v := range rec {
+				if !keep[idx] {
+					continue
+				}
 
 				// Check if v represents a nil value
 				if len(options) > 0 && options[0].NilValue != nil {
@@ -216,7 +291,7 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 				// Check if the datatype is dictated
 				if len(options) > 0 && len(options[0].DictateDataType) > 0 {
 
-					name := df.Names(dataframe.DontLock)[idx]
+					name := headers[idx]
 
 					// Check if a datatype is dictated
 					typ, exists := options[0].DictateDataType[name]
@@ -233,38 +308,44 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 						} else if v == "FALSE" || v == "false" || v == "False" || v == "0" {
 							insertVals = append(insertVals, int64(0))
 						} else {
-							return nil, fmt.Errorf("can't force string: %s to bool. row: %d field: %s", v, row-1, name)
+							return nil, false, fmt.Errorf("can't force string: %s to bool. row: %d field: %s", v, row-1, name)
 						}
 					case int64:
 						i, err := strconv.ParseInt(v, 10, 64)
 						if err != nil {
-							return nil, fmt.Errorf("can't force string: %s to int64. row: %d field: %s", v, row-1, name)
+							return nil, false, fmt.Errorf("can't force string: %s to int64. row: %d field: %s", v, row-1, name)
 						}
 						insertVals = append(insertVals, i)
 					case float64:
 						f, err := strconv.ParseFloat(v, 64)
 						if err != nil {
-							return nil, fmt.Errorf("can't force string: %s to float64. row: %d field: %s", v, row-1, name)
+							return nil, false, fmt.Errorf("can't force string: %s to float64. row: %d field: %s", v, row-1, name)
 						}
 						insertVals = append(insertVals, f)
 					case time.Time:
-						t, err := time.Parse(time.RFC3339, v)
+						t, err := parseTime(time.RFC3339, v, options[0].Location)
 						if err != nil {
 							// Assume unix timestamp
 							sec, err := strconv.ParseInt(v, 10, 64)
 							if err != nil {
-								return nil, fmt.Errorf("can't force string: %s to time.Time (%s). row: %d field: %s", v, time.RFC3339, row-1, name)
+								return nil, false, fmt.Errorf("can't force string: %s to time.Time (%s). row: %d field: %s", v, time.RFC3339, row-1, name)
 							}
 							insertVals = append(insertVals, time.Unix(sec, 0))
 						} else {
 							insertVals = append(insertVals, t)
 						}
+					case time.Duration:
+						d, err := dataframe.ParseDuration(v)
+						if err != nil {
+							return nil, false, fmt.Errorf("can't force string: %s to time.Duration. row: %d field: %s", v, row-1, name)
+						}
+						insertVals = append(insertVals, d)
 					case dataframe.NewSerieser:
 						insertVals = append(insertVals, v)
 					case Converter:
 						cv, err := T.ConverterFunc(v)
 						if err != nil {
-							return nil, fmt.Errorf("can't force string: %s to generic data type. row: %d field: %s", v, row-1, name)
+							return nil, false, fmt.Errorf("can't force string: %s to generic data type. row: %d field: %s", v, row-1, name)
 						}
 						insertVals = append(insertVals, cv)
 					default:
@@ -280,13 +361,30 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 				insertVals = append(insertVals, v)
 			}
 
+			if applyFilter {
+				vals := make(map[interface{}]interface{}, 2*len(insertVals))
+				for idx, v := range insertVals {
+					vals[idx] = v
+					vals[df.Series[idx].Name(dataframe.DontLock)] = v
+				}
+
+				fa, err := scan.Filter(vals, row-1, -1)
+				if err != nil {
+					return nil, false, err
+				}
+				if fa == dataframe.DROP {
+					row++
+					continue
+				}
+			}
+
 			df.Append(&dataframe.DontLock, insertVals...)
 		}
 		row++
 	}
 
 	if df == nil {
-		return nil, dataframe.ErrNoRows
+		return nil, false, dataframe.ErrNoRows
 	}
 
 	// Convert inferred series to actual series
@@ -304,5 +402,5 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 		}
 	}
 
-	return df, nil
+	return df, applyFilter, nil
 }
@@ -6,6 +6,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -99,3 +100,103 @@ func TestLoadFromCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadFromCSVLocation(t *testing.T) {
+	loc := time.FixedZone("AEST", 10*60*60)
+
+	data := "local,utc\n2020-01-02 03:04:05,2020-01-02T03:04:05Z\n"
+
+	df, err := LoadFromCSV(context.TODO(), strings.NewReader(data), CSVLoadOptions{
+		InferDataTypes: true,
+		DictateDataType: map[string]interface{}{
+			"utc": time.Time{},
+		},
+		Location: loc,
+	})
+	assert.Nil(t, err)
+
+	expected := []time.Time{
+		time.Date(2020, 1, 2, 3, 4, 5, 0, loc),  // Interpreted as being in loc
+		time.Date(2020, 1, 2, 13, 4, 5, 0, loc), // Converted to loc
+	}
+
+	for i, s := range df.Series {
+		ts, ok := s.(*dataframe.SeriesTime)
+		if !ok {
+			t.Fatalf("wrong type: expected: *dataframe.SeriesTime actual: %T", s)
+		}
+		assert.Equal(t, loc, ts.Location)
+		assert.Equal(t, expected[i], ts.Value(0))
+	}
+}
+
+func TestScanCSV(t *testing.T) {
+	ctx := context.Background()
+
+	data := "city,year,sales,notes\nSydney,2020,10,a\nMelbourne,2021,20,b\nSydney,2021,30,c\n"
+
+	var calls int
+	after2020 := func(vals map[interface{}]interface{}, row, nRows int) (dataframe.FilterAction, error) {
+		calls++
+		if vals["year"].(int64) > 2020 {
+			return dataframe.KEEP, nil
+		}
+		return dataframe.DROP, nil
+	}
+
+	for _, infer := range []bool{false, true} {
+		calls = 0
+
+		df, err := ScanCSV(strings.NewReader(data), CSVLoadOptions{
+			InferDataTypes: infer,
+			DictateDataType: map[string]interface{}{
+				"year":  int64(0),
+				"sales": float64(0),
+			},
+		}).Filter(after2020, "year").Select("city", "sales").Collect(ctx)
+		assert.Nil(t, err)
+
+		expected := dataframe.NewDataFrame(
+			dataframe.NewSeriesString("city", nil, "Melbourne", "Sydney"),
+			dataframe.NewSeriesFloat64("sales", nil, 20.0, 30.0),
+		)
+		assertEqualDS(t, expected, df)
+
+		// Filter is applied once per row while loading
+		assert.Equal(t, 3, calls)
+	}
+
+	// A LazyFrame can be collected more than once
+	lf := ScanCSV(strings.NewReader(data), CSVLoadOptions{
+		DictateDataType: map[string]interface{}{"year": int64(0)},
+	}).Select("city", "year")
+
+	for i := 0; i < 2; i++ {
+		df, err := lf.Collect(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, df.NRows())
+	}
+}
+
+func TestLoadFromCSVSchema(t *testing.T) {
+	ctx := context.Background()
+
+	schema := dataframe.Schema{
+		Fields: []dataframe.SchemaField{
+			{Name: "n", Type: 0},
+			{Name: "d", Type: time.Duration(0)},
+		},
+	}
+
+	df, err := LoadFromCSV(ctx, strings.NewReader("n,d\n1,1h30m\n2,PT1S\n"), CSVLoadOptions{DictateDataType: schema.DictateDataType()})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	expected := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("n", nil, 1, 2),
+		dataframe.NewSeriesDuration("d", nil, 90*time.Minute, time.Second),
+	)
+	assertEqualDS(t, expected, df)
+	assert.Nil(t, schema.Validate(ctx, df))
+}
@@ -35,9 +35,10 @@ type inferSeries struct {
 	knownSize *int
 	initCap   int
 	added     int
+	loc       *time.Location
 }
 
-func newInferSeries(name string, knownSize *int) *inferSeries {
+func newInferSeries(name string, knownSize *int, loc *time.Location) *inferSeries {
 
 	initCap := 5
 	if knownSize != nil {
@@ -48,7 +49,7 @@ func newInferSeries(name string, knownSize *int) *inferSeries {
 	}
 	init := &dataframe.SeriesInit{Capacity: initCap}
 
-	is := &inferSeries{knownSize: knownSize, initCap: initCap}
+	is := &inferSeries{knownSize: knownSize, initCap: initCap, loc: loc}
 
 	is.series = []dataframe.Series{}
 
@@ -58,6 +59,7 @@ func newInferSeries(name string, knownSize *int) *inferSeries {
 	for _, layout := range timelayouts {
 		ts := dataframe.NewSeriesTime(name, init)
 		ts.Layout = layout
+		ts.Location = loc
 		is.series = append(is.series, ts)
 	}
 	is.series = append(is.series, dataframe.NewSeriesString(name, init))
@@ -119,6 +121,8 @@ func (is *inferSeries) Insert(row int, val interface{}, opts ...dataframe.Option
 				}
 			case *dataframe.SeriesTime:
 				ns = dataframe.NewSeriesTime(x.Name(dataframe.DontLock), init)
+				ns.(*dataframe.SeriesTime).Layout = x.Layout
+				ns.(*dataframe.SeriesTime).Location = x.Location
 
 				for {
 					row, val, _ := iterator()
@@ -172,7 +176,7 @@ func (is *inferSeries) Insert(row int, val interface{}, opts ...dataframe.Option
 		case *dataframe.SeriesString:
 			s.Append(val, dataframe.DontLock)
 		case *dataframe.SeriesTime:
-			t, err := time.Parse(x.Layout, val.(string))
+			t, err := parseTime(x.Layout, val.(string), is.loc)
 			if err != nil {
 				toRemove = append(toRemove, i)
 			} else {
@@ -40,11 +40,28 @@ type JSONLoadOptions struct {
 	//
 	// NOTE: Not implemented.
 	Path string
+
+	// Location is used to interpret times that don't contain time zone information.
+	// It is also set as the Location of every SeriesTime created. When nil, UTC is assumed.
+	Location *time.Location
+
+	// Nested can be set to true to load objects into a SeriesStruct instead of flattening them.
+	// The fields of a SeriesStruct are determined by the first non-nil object. Fields that are nil in that
+	// object are stored in a SeriesString. Unknown fields encountered afterwards are treated like unknown fields
+	// at the root level (see ErrorOnUnknownFields).
+	Nested bool
 }
 
 // LoadFromJSON will load data from a jsonl file or a JSON array.
 // The first row determines which fields will be imported for subsequent rows.
 //
+// Nested objects are flattened using dot notation (eg. {"a": {"b": 1}} is loaded into a Series named "a.b")
+// unless the Nested option is set. dataframe.Unflatten can also be used to restore the hierarchy, but it can't
+// distinguish a nil object from an object whose fields are all nil.
+//
+// Arrays are loaded into a SeriesList. The type of the elements is determined by the first
+// non-nil element of the array in the first row. Arrays containing arrays or objects are not supported.
+//
 // See: https://jsonlines.org for details on the file format.
 func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptions) (*dataframe.DataFrame, error) {
 
@@ -111,14 +128,24 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 		}
 	}
 
-	var df *dataframe.DataFrame
+	var (
+		df             *dataframe.DataFrame
+		nested         bool
+		errorOnUnknown bool
+	)
 
-	rowIter, jf, err := readJSON(r)
+	if len(options) > 0 {
+		nested = options[0].Nested
+		errorOnUnknown = options[0].ErrorOnUnknownFields
+	}
+
+	rowIter, jf, err := readJSON(r, nested)
 	if err != nil {
 		return nil, err
 	}
 
 	nameToIdx := map[string]int{} // map series name to index in df
+	undecided := map[int]bool{}   // series whose data type was not determined by the first row
 
 	for {
 		if err := ctx.Err(); err != nil {
@@ -170,6 +197,8 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 							seriess = append(seriess, dataframe.NewSeriesString(name, init))
 						case time.Time:
 							seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+						case time.Duration:
+							seriess = append(seriess, dataframe.NewSeriesDuration(name, init))
 						case dataframe.NewSerieser:
 							seriess = append(seriess, T.NewSeries(name, init))
 						case Converter:
@@ -187,8 +216,9 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 				}
 
 				// Not dictated, so determine data type from actual values
-				switch rowVals[name].(type) {
+				switch v := rowVals[name].(type) {
 				case nil:
+					undecided[len(seriess)] = true
 					seriess = append(seriess, dataframe.NewSeriesString(name, init))
 				case bool:
 					seriess = append(seriess, dataframe.NewSeriesInt64(name, init))
@@ -197,6 +227,25 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 				case json.Number:
 					// Assume float
 					seriess = append(seriess, dataframe.NewSeriesFloat64(name, init))
+				case []interface{}:
+					child, err := jsonListChild(rowVals[name].([]interface{}))
+					if err != nil {
+						return nil, fmt.Errorf("row: 0 - %w", err)
+					}
+					if child == nil {
+						undecided[len(seriess)] = true
+						child = dataframe.NewSeriesString("", nil)
+					}
+					seriess = append(seriess, dataframe.NewSeriesList(name, child, init))
+				case map[string]interface{}:
+					if !nested {
+						return nil, errors.New("row: 0 - array or object detected for value")
+					}
+					ss, err := jsonStructSeries(name, v, init)
+					if err != nil {
+						return nil, fmt.Errorf("row: 0 - %w", err)
+					}
+					seriess = append(seriess, ss)
 				default:
 					return nil, errors.New("row: 0 - array or object detected for value")
 				}
@@ -208,6 +257,9 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 			for i, v := range seriess {
 				nameToIdx[v.Name(dataframe.DontLock)] = i
 			}
+			if len(options) > 0 {
+				setLocation(seriess, options[0].Location)
+			}
 			df = dataframe.NewDataFrame(seriess...)
 		} else {
 			// case: row != 0
@@ -222,13 +274,20 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 		// Load data
 		for name, val := range rowVals {
 			idx, exists := nameToIdx[name]
+			if !exists {
+				// unknown field
+				if errorOnUnknown {
+					return nil, fmt.Errorf("unknown field encountered. row: %d field: %s", *row, name)
+				}
+				continue
+			}
 
 			var insertVal interface{} = val
 
 			if len(options) > 0 && len(options[0].DictateDataType) > 0 {
 				typ, exists := options[0].DictateDataType[name]
 				if exists {
-					insertVal, err = dictateForce(*row, name, typ, val)
+					insertVal, err = dictateForce(*row, name, typ, val, options[0].Location)
 					if err != nil {
 						return nil, err
 					}
@@ -236,6 +295,22 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 				}
 			}
 
+			if _, isList := df.Series[idx].(*dataframe.SeriesList); isList {
+				if _, isArray := val.([]interface{}); !isArray && val != nil {
+					return nil, fmt.Errorf("row: %d - expected array for %s", *row, name)
+				}
+			} else if _, isStruct := df.Series[idx].(*dataframe.SeriesStruct); isStruct {
+				if _, isObject := val.(map[string]interface{}); !isObject && val != nil {
+					return nil, fmt.Errorf("row: %d - expected object for %s", *row, name)
+				}
+			} else {
+				switch val.(type) {
+				case nil, []interface{}, map[string]interface{}:
+				default:
+					delete(undecided, idx) // decided by the first non-nil value
+				}
+			}
+
 			switch v := val.(type) {
 			case nil, bool, string, int, float64, int64:
 				insertVal = v
@@ -245,23 +320,25 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 					return nil, fmt.Errorf("row: %d - invalid value for %s", *row, name)
 				}
 				insertVal = x
+			case []interface{}:
+				insertVal, err = jsonListValue(df, idx, undecided, *row, name, v)
+				if err != nil {
+					return nil, err
+				}
+			case map[string]interface{}:
+				if !nested {
+					return nil, fmt.Errorf("row: %d - array or object detected for value", *row)
+				}
+				insertVal, err = jsonStructValue(df, idx, undecided, *row, name, v, errorOnUnknown)
+				if err != nil {
+					return nil, err
+				}
 			default:
 				return nil, fmt.Errorf("row: %d - array or object detected for value", *row)
 			}
 
 		STORE_VALUE:
-			if jf == jsonlArray {
-				df.Series[idx].Update(*row, insertVal, dataframe.DontLock)
-			} else {
-				if !exists {
-					// unknown field
-					if len(options) > 0 && options[0].ErrorOnUnknownFields {
-						return nil, fmt.Errorf("unknown field encountered. row: %d field: %s", *row, name)
-					}
-					continue
-				}
-				df.Series[idx].Update(*row, insertVal, dataframe.DontLock)
-			}
+			df.Series[idx].Update(*row, insertVal, dataframe.DontLock)
 		}
 	}
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"time"
 
@@ -64,20 +65,22 @@ func detectJSONDataFormat(r io.ReadSeeker) (jsonFormat, error) {
 	panic("should not reach here")
 }
 
-func readJSON(r io.ReadSeeker) (jsonRow, jsonFormat, error) {
+// readJSON returns a function that reads the rows of r. Objects are flattened using dot notation
+// unless nested is set.
+func readJSON(r io.ReadSeeker, nested bool) (jsonRow, jsonFormat, error) {
 	fmt, err := detectJSONDataFormat(r)
 	if err != nil {
 		return nil, fmt, err
 	}
 
 	if fmt == jsonArray {
-		return processArray(r), fmt, nil
+		return processArray(r, nested), fmt, nil
 	} else {
-		return processLines(r), fmt, nil
+		return processLines(r, nested), fmt, nil
 	}
 }
 
-func processArray(r io.ReadSeeker) jsonRow {
+func processArray(r io.ReadSeeker, nested bool) jsonRow {
 	dec := json.NewDecoder(r)
 	dec.UseNumber()
 	dec.Token()
@@ -90,6 +93,9 @@ func processArray(r io.ReadSeeker) jsonRow {
 				return nil, nil, err
 			}
 			count++
+			if nested {
+				return &count, m, nil
+			}
 			return &count, parseObject(m, ""), nil
 		} else {
 			// No more rows
@@ -98,7 +104,7 @@ func processArray(r io.ReadSeeker) jsonRow {
 	}
 }
 
-func processLines(r io.ReadSeeker) jsonRow {
+func processLines(r io.ReadSeeker, nested bool) jsonRow {
 	dec := json.NewDecoder(r)
 	dec.UseNumber()
 	count := -1
@@ -161,7 +167,7 @@ func processLines(r io.ReadSeeker) jsonRow {
 			} else {
 				m = rowVals
 			}
-		} else {
+		} else if !nested {
 			// We have encountered jsonl in OBJECT format.
 			m = parseObject(m.(map[string]interface{}), "")
 		}
@@ -171,7 +177,7 @@ func processLines(r io.ReadSeeker) jsonRow {
 	}
 }
 
-func dictateForce(row int, name string, typ interface{}, val interface{}) (insertVal interface{}, _ error) {
+func dictateForce(row int, name string, typ interface{}, val interface{}, loc *time.Location) (insertVal interface{}, _ error) {
 	switch T := typ.(type) {
 	case nil:
 		panic("invalid dictated datatype for " + name)
@@ -303,7 +309,7 @@ func dictateForce(row int, name string, typ interface{}, val interface{}) (inser
 		case nil:
 			insertVal = nil
 		case string:
-			t, err := time.Parse(time.RFC3339, v)
+			t, err := parseTime(time.RFC3339, v, loc)
 			if err != nil {
 				return nil, fmt.Errorf("can't force string: %s to time.Time (%s). row: %d field: %s", v, time.RFC3339, row, name)
 			}
@@ -318,11 +324,48 @@ func dictateForce(row int, name string, typ interface{}, val interface{}) (inser
 		default:
 			return nil, fmt.Errorf("can't force %T to time.Time. row: %d field: %s", v, row, name)
 		}
+	case time.Duration:
+		// Force v to duration
+		switch v := val.(type) {
+		case nil:
+			insertVal = nil
+		case string:
+			d, err := dataframe.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("can't force string: %s to time.Duration. row: %d field: %s", v, row, name)
+			}
+			insertVal = d
+		case json.Number:
+			// Assume nanoseconds
+			ns, err := v.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("can't force number to int64 (nanoseconds). row: %d field: %s", row, name)
+			}
+			insertVal = time.Duration(ns)
+		default:
+			return nil, fmt.Errorf("can't force %T to time.Duration. row: %d field: %s", v, row, name)
+		}
 	case dataframe.NewSerieser:
+		if _, ok := T.(*dataframe.SeriesList); ok {
+			if _, isArray := val.([]interface{}); !isArray && val != nil {
+				return nil, fmt.Errorf("can't force %T to list. row: %d field: %s", val, row, name)
+			}
+		}
+
 		// Force v to string
 		switch v := val.(type) {
 		case nil:
 			insertVal = nil
+		case []interface{}:
+			ls, ok := T.(*dataframe.SeriesList)
+			if !ok {
+				return nil, fmt.Errorf("can't force array to %T. row: %d field: %s", T, row, name)
+			}
+			l, err := jsonList(row, name, ls.NewChildSeries("", nil), v)
+			if err != nil {
+				return nil, err
+			}
+			insertVal = l
 		case string:
 			insertVal = v
 		case json.Number:
@@ -346,3 +389,268 @@ func dictateForce(row int, name string, typ interface{}, val interface{}) (inser
 	}
 	return
 }
+
+// jsonListChild returns a Series of the type of the first non-nil element of a JSON array.
+// When the array does not contain a non-nil element, nil is returned (the type is undecided).
+func jsonListChild(vals []interface{}) (dataframe.Series, error) {
+	for _, v := range vals {
+		switch v.(type) {
+		case nil:
+			continue
+		case bool:
+			return dataframe.NewSeriesInt64("", nil), nil
+		case string:
+			return dataframe.NewSeriesString("", nil), nil
+		case json.Number:
+			return dataframe.NewSeriesFloat64("", nil), nil
+		default:
+			return nil, errors.New("array or object detected in array")
+		}
+	}
+	return nil, nil
+}
+
+// jsonList converts the elements of a JSON array so that they can be stored in a SeriesList
+// with the given child Series.
+func jsonList(row int, name string, child dataframe.Series, vals []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(vals))
+	for _, v := range vals {
+		if v == nil {
+			out = append(out, nil)
+			continue
+		}
+
+		var (
+			e   interface{}
+			err error
+		)
+
+		switch x := v.(type) {
+		case []interface{}, map[string]interface{}:
+			return nil, fmt.Errorf("row: %d - array or object detected in array for %s", row, name)
+		case json.Number:
+			switch child.(type) {
+			case *dataframe.SeriesFloat64:
+				e, err = x.Float64()
+			case *dataframe.SeriesInt64:
+				e, err = x.Int64()
+			default:
+				e, err = jsonElement(child, x.String())
+			}
+		case bool:
+			switch child.(type) {
+			case *dataframe.SeriesFloat64, *dataframe.SeriesString:
+				err = errors.New("bool")
+			case *dataframe.SeriesInt64:
+				e = int64(dataframe.B(x))
+			default:
+				e, err = jsonElement(child, x)
+			}
+		case string:
+			switch child.(type) {
+			case *dataframe.SeriesString:
+				e = x
+			case *dataframe.SeriesFloat64, *dataframe.SeriesInt64:
+				err = errors.New("string")
+			default:
+				e, err = jsonElement(child, x)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row: %d - invalid element in array for %s: %v (expected %s)", row, name, v, child.Type())
+		}
+
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// jsonElement checks if val can be stored in the child Series.
+func jsonElement(child dataframe.Series, val interface{}) (_ interface{}, rErr error) {
+	defer func() {
+		if x := recover(); x != nil {
+			rErr = fmt.Errorf("%v", x)
+		}
+	}()
+
+	cs := child.(dataframe.NewSerieser).NewSeries("", &dataframe.SeriesInit{Capacity: 1})
+	cs.Append(val, dataframe.DontLock)
+	return val, nil
+}
+
+// jsonListValue converts a JSON array so that it can be stored in the Series at idx.
+// A Series whose type was not determined by the previous rows (because its values were nil or arrays without
+// non-nil elements) is replaced by a SeriesList of the appropriate type.
+func jsonListValue(df *dataframe.DataFrame, idx int, undecided map[int]bool, row int, name string, vals []interface{}) ([]interface{}, error) {
+	ls, isList := df.Series[idx].(*dataframe.SeriesList)
+
+	if undecided[idx] {
+		child, err := jsonListChild(vals)
+		if err != nil {
+			return nil, fmt.Errorf("row: %d - %w", row, err)
+		}
+
+		if child != nil || !isList {
+			if child == nil {
+				child = dataframe.NewSeriesString("", nil) // still undecided
+			} else {
+				delete(undecided, idx)
+			}
+
+			// The existing values are nil or only contain nil elements
+			n := df.NRows(dataframe.DontLock)
+			nls := dataframe.NewSeriesList(name, child, &dataframe.SeriesInit{Capacity: n})
+			for r := 0; r < n; r++ {
+				var v interface{}
+				if isList {
+					v = ls.Value(r, dataframe.DontLock)
+				}
+				nls.Append(v, dataframe.DontLock)
+			}
+
+			df.Series[idx] = nls
+			ls, isList = nls, true
+		}
+	}
+
+	if !isList {
+		return nil, fmt.Errorf("row: %d - array detected for %s", row, name)
+	}
+
+	return jsonList(row, name, ls.NewChildSeries("", nil), vals)
+}
+
+// jsonStructSeries creates a SeriesStruct for a JSON object. The type of each field is determined by its value.
+// Fields with a nil value are stored in a SeriesString.
+func jsonStructSeries(name string, obj map[string]interface{}, init *dataframe.SeriesInit) (*dataframe.SeriesStruct, error) {
+	if len(obj) == 0 {
+		return nil, fmt.Errorf("empty object detected for %s", name)
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]dataframe.Series, 0, len(keys))
+	for _, k := range keys {
+		var f dataframe.Series
+
+		switch v := obj[k].(type) {
+		case nil, string:
+			f = dataframe.NewSeriesString(k, nil)
+		case bool:
+			f = dataframe.NewSeriesInt64(k, nil)
+		case json.Number:
+			// Assume float
+			f = dataframe.NewSeriesFloat64(k, nil)
+		case []interface{}:
+			child, err := jsonListChild(v)
+			if err != nil {
+				return nil, err
+			}
+			if child == nil {
+				child = dataframe.NewSeriesString("", nil)
+			}
+			f = dataframe.NewSeriesList(k, child, nil)
+		case map[string]interface{}:
+			ss, err := jsonStructSeries(name+"."+k, v, nil)
+			if err != nil {
+				return nil, err
+			}
+			ss.Rename(k)
+			f = ss
+		default:
+			return nil, fmt.Errorf("unknown value detected for %s.%s", name, k)
+		}
+
+		fields = append(fields, f)
+	}
+
+	return dataframe.NewSeriesStruct(name, init, fields...), nil
+}
+
+// jsonStruct converts a JSON object so that it can be stored in the SeriesStruct ss.
+// Unknown fields are ignored unless errorOnUnknown is set.
+func jsonStruct(row int, name string, ss *dataframe.SeriesStruct, obj map[string]interface{}, errorOnUnknown bool) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(obj))
+
+	for k, v := range obj {
+		fieldName := name + "." + k
+
+		f, err := ss.Field(k)
+		if err != nil {
+			if errorOnUnknown {
+				return nil, fmt.Errorf("unknown field encountered. row: %d field: %s", row, fieldName)
+			}
+			continue
+		}
+
+		switch x := v.(type) {
+		case json.Number:
+			fv, err := x.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("row: %d - invalid value for %s", row, fieldName)
+			}
+			out[k] = fv
+		case []interface{}:
+			ls, ok := f.(*dataframe.SeriesList)
+			if !ok {
+				return nil, fmt.Errorf("row: %d - array detected for %s", row, fieldName)
+			}
+			out[k], err = jsonList(row, fieldName, ls.NewChildSeries("", nil), x)
+			if err != nil {
+				return nil, err
+			}
+		case map[string]interface{}:
+			fs, ok := f.(*dataframe.SeriesStruct)
+			if !ok {
+				return nil, fmt.Errorf("row: %d - object detected for %s", row, fieldName)
+			}
+			out[k], err = jsonStruct(row, fieldName, fs, x, errorOnUnknown)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			switch f.(type) {
+			case *dataframe.SeriesList:
+				if v != nil {
+					return nil, fmt.Errorf("row: %d - expected array for %s", row, fieldName)
+				}
+			case *dataframe.SeriesStruct:
+				if v != nil {
+					return nil, fmt.Errorf("row: %d - expected object for %s", row, fieldName)
+				}
+			}
+			out[k] = v
+		}
+	}
+
+	return out, nil
+}
+
+// jsonStructValue converts a JSON object so that it can be stored in the SeriesStruct at idx.
+// If the data type of the Series has not been decided, it is replaced by a SeriesStruct.
+func jsonStructValue(df *dataframe.DataFrame, idx int, undecided map[int]bool, row int, name string, obj map[string]interface{}, errorOnUnknown bool) (map[string]interface{}, error) {
+	ss, isStruct := df.Series[idx].(*dataframe.SeriesStruct)
+	_, isList := df.Series[idx].(*dataframe.SeriesList)
+
+	if undecided[idx] && !isStruct && !isList {
+		// The existing values are nil
+		nss, err := jsonStructSeries(name, obj, &dataframe.SeriesInit{Size: df.NRows(dataframe.DontLock)})
+		if err != nil {
+			return nil, fmt.Errorf("row: %d - %w", row, err)
+		}
+
+		delete(undecided, idx)
+		df.Series[idx] = nss
+		ss, isStruct = nss, true
+	}
+
+	if !isStruct {
+		return nil, fmt.Errorf("row: %d - object detected for %s", row, name)
+	}
+
+	return jsonStruct(row, name, ss, obj, errorOnUnknown)
+}
@@ -0,0 +1,257 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFromJSONLists(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		data    string
+		options JSONLoadOptions
+		want    *dataframe.DataFrame
+		wantErr bool
+	}{
+		{
+			name: "Should load arrays into a SeriesList",
+			data: `[{"x":[1,2.5]},{"x":null},{"x":[]},{"x":[3,null]}]`,
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesList("x", dataframe.NewSeriesFloat64("", nil), nil, []float64{1, 2.5}, nil, []float64{}, []interface{}{3.0, nil}),
+			),
+		},
+		{
+			name: "Should load arrays of bools into a SeriesList of int64",
+			data: `{"x":[true,false]}` + "\n" + `{"x":[false]}`,
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesList("x", dataframe.NewSeriesInt64("", nil), nil, []int64{1, 0}, []int64{0}),
+			),
+		},
+		{
+			name: "Should decide the type from a later row when the first value is nil",
+			data: `[{"x":null},{"x":["a","b"]}]`,
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesList("x", dataframe.NewSeriesString("", nil), nil, nil, []string{"a", "b"}),
+			),
+		},
+		{
+			name: "Should decide the type from a later row when the first array is empty",
+			data: `[{"x":[]},{"x":[null]},{"x":null},{"x":[1]}]`,
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesList("x", dataframe.NewSeriesFloat64("", nil), nil, []float64{}, []interface{}{nil}, nil, []float64{1}),
+			),
+		},
+		{
+			name: "Should load arrays into a dictated SeriesList",
+			data: `[{"x":[1,2]},{"x":null}]`,
+			options: JSONLoadOptions{
+				DictateDataType: map[string]interface{}{"x": dataframe.NewSeriesList("", dataframe.NewSeriesInt64("", nil), nil)},
+			},
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesList("x", dataframe.NewSeriesInt64("", nil), nil, []int64{1, 2}, nil),
+			),
+		},
+		{
+			name:    "Should error when the elements have different types",
+			data:    `[{"x":[1,"a"]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Should error when a later element has a different type",
+			data:    `[{"x":[1]},{"x":[true]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Should error on nested arrays",
+			data:    `[{"x":[[1]]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Should error when an array follows a string",
+			data:    `[{"x":"a"},{"x":[1,2]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "Should error when a string follows an array",
+			data:    `[{"x":[1,2]},{"x":"a"}]`,
+			wantErr: true,
+		},
+		{
+			name: "Should error when a dictated SeriesList receives a string",
+			data: `[{"x":"a"}]`,
+			options: JSONLoadOptions{
+				DictateDataType: map[string]interface{}{"x": dataframe.NewSeriesList("", dataframe.NewSeriesInt64("", nil), nil)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadFromJSON(ctx, strings.NewReader(tt.data), tt.options)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assertEqualDS(t, tt.want, got)
+		})
+	}
+}
+
+// appendStruct appends vals to s.
+func appendStruct(s *dataframe.SeriesStruct, vals ...interface{}) *dataframe.SeriesStruct {
+	for _, v := range vals {
+		s.Append(v)
+	}
+	return s
+}
+
+func TestLoadFromJSONNested(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		data    string
+		options JSONLoadOptions
+		want    *dataframe.DataFrame
+		wantErr bool
+	}{
+		{
+			name: "Should flatten objects by default",
+			data: `[{"a":{"b":1,"c":"x"}},{"a":{"b":2}}]`,
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesFloat64("a.b", nil, 1.0, 2.0),
+				dataframe.NewSeriesString("a.c", nil, "x", nil),
+			),
+		},
+		{
+			name:    "Should load objects into a SeriesStruct",
+			data:    `[{"a":{"b":1,"c":{"d":"x"}}},{"a":null},{"a":{"c":null}}]`,
+			options: JSONLoadOptions{Nested: true},
+			want: dataframe.NewDataFrame(
+				appendStruct(
+					dataframe.NewSeriesStruct("a", nil,
+						dataframe.NewSeriesFloat64("b", nil),
+						dataframe.NewSeriesStruct("c", nil, dataframe.NewSeriesString("d", nil)),
+					),
+					map[string]interface{}{"b": 1.0, "c": map[string]interface{}{"d": "x"}},
+					nil,
+					map[string]interface{}{"c": nil},
+				),
+			),
+		},
+		{
+			name:    "Should decide the fields from a later row when the first value is nil",
+			data:    `[{"a":null},{"a":{"b":[1,2]}}]`,
+			options: JSONLoadOptions{Nested: true},
+			want: dataframe.NewDataFrame(
+				appendStruct(
+					dataframe.NewSeriesStruct("a", nil, dataframe.NewSeriesList("b", dataframe.NewSeriesFloat64("", nil), nil)),
+					nil,
+					map[string]interface{}{"b": []float64{1, 2}},
+				),
+			),
+		},
+		{
+			name:    "Should ignore unknown fields",
+			data:    `[{"a":{"b":1}},{"a":{"b":2,"c":3}}]`,
+			options: JSONLoadOptions{Nested: true},
+			want: dataframe.NewDataFrame(
+				dataframe.NewSeriesStruct("a", nil, dataframe.NewSeriesFloat64("b", nil, 1.0, 2.0)),
+			),
+		},
+		{
+			name:    "Should error on unknown fields",
+			data:    `[{"a":{"b":1}},{"a":{"b":2,"c":3}}]`,
+			options: JSONLoadOptions{Nested: true, ErrorOnUnknownFields: true},
+			wantErr: true,
+		},
+		{
+			name:    "Should error when a string follows an object",
+			data:    `[{"a":{"b":1}},{"a":"x"}]`,
+			options: JSONLoadOptions{Nested: true},
+			wantErr: true,
+		},
+		{
+			name:    "Should error on empty objects",
+			data:    `[{"a":{}}]`,
+			options: JSONLoadOptions{Nested: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadFromJSON(ctx, strings.NewReader(tt.data), tt.options)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assertEqualDS(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONNestedRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	data := `{"address":{"city":"Sydney","geo":{"lat":1.5,"lng":2.5},"tags":["a","b"]},"id":1}
+{"address":null,"id":2}
+{"address":{"city":null,"geo":null,"tags":[]},"id":3}
+`
+
+	df, err := LoadFromJSON(ctx, strings.NewReader(data), JSONLoadOptions{Nested: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if !assert.NoError(t, exports.ExportToJSON(ctx, &buf, df)) {
+		return
+	}
+	assertEqualJSONL(t, data, buf.String())
+
+	// NullString is applied to nil values within objects and arrays
+	null := "NA"
+	buf.Reset()
+	if !assert.NoError(t, exports.ExportToJSON(ctx, &buf, df, exports.JSONExportOptions{NullString: &null})) {
+		return
+	}
+	assertEqualJSONL(t, `{"address":{"city":"Sydney","geo":{"lat":1.5,"lng":2.5},"tags":["a","b"]},"id":1}
+{"address":"NA","id":2}
+{"address":{"city":"NA","geo":"NA","tags":[]},"id":3}
+`, buf.String())
+}
+
+// assertEqualJSONL asserts that each line of expected and actual contain the same JSON value.
+func assertEqualJSONL(t *testing.T, expected, actual string) {
+	t.Helper()
+
+	decode := func(s string) []interface{} {
+		out := []interface{}{}
+		dec := json.NewDecoder(strings.NewReader(s))
+		for dec.More() {
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+			}
+			out = append(out, v)
+		}
+		return out
+	}
+
+	assert.Equal(t, decode(expected), decode(actual))
+}
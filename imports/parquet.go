@@ -11,6 +11,7 @@ import (
 
 	"github.com/xitongsys/parquet-go/parquet"
 	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
 	"github.com/xitongsys/parquet-go/source"
 
 	dataframe "github.com/rocketlaunchr/dataframe-go"
@@ -21,6 +22,7 @@ type ParquetLoadOptions struct {
 }
 
 // LoadFromParquet will load data from a parquet file.
+// LIST columns are loaded into a SeriesList and nested groups are loaded into a SeriesStruct.
 //
 // NOTE: This function is experimental and the implementation is likely to change.
 //
@@ -37,6 +39,36 @@ type ParquetLoadOptions struct {
 //  }
 //
 func LoadFromParquet(ctx context.Context, src source.ParquetFile, opts ...ParquetLoadOptions) (*dataframe.DataFrame, error) {
+	return loadFromParquet(ctx, src, nil, opts...)
+}
+
+// ScanParquet returns a LazyFrame that loads data from a parquet file when Collect is called.
+// Only the Series and rows required by the LazyFrame are stored.
+//
+// NOTE: This function is experimental and the implementation is likely to change.
+func ScanParquet(src source.ParquetFile, opts ...ParquetLoadOptions) *dataframe.LazyFrame {
+	return dataframe.NewLazyFrame(&parquetSource{src: src, options: opts})
+}
+
+type parquetSource struct {
+	src     source.ParquetFile
+	options []ParquetLoadOptions
+}
+
+func (s *parquetSource) String() string {
+	return "Parquet"
+}
+
+func (s *parquetSource) Scan(ctx context.Context, opts dataframe.ScanOptions) (*dataframe.DataFrame, bool, error) {
+	df, err := loadFromParquet(ctx, s.src, &opts, s.options...)
+	if err != nil {
+		return nil, false, err
+	}
+	return df, opts.Filter != nil, nil
+}
+
+// loadFromParquet will load data from a parquet file. When scan is provided, only the required Series and rows are stored.
+func loadFromParquet(ctx context.Context, src source.ParquetFile, scan *dataframe.ScanOptions, options ...ParquetLoadOptions) (*dataframe.DataFrame, error) {
 	pr, err := reader.NewParquetReader(src, nil, int64(runtime.NumCPU()))
 	if err != nil {
 		return nil, err
@@ -74,6 +106,14 @@ func LoadFromParquet(ctx context.Context, src source.ParquetFile, opts ...Parque
 		goFieldNameToActual[strings.TrimPrefix(goName, goRootName+".")] = strings.TrimPrefix(pr.SchemaHandler.InPathToExPath[goName], actualRootName+".")
 	}
 
+	// The value columns of a LIST column are nested
+	for i := 0; i < pr.ObjType.NumField(); i++ {
+		goName := pr.ObjType.Field(i).Name
+		if _, exists := goFieldNameToActual[goName]; !exists {
+			goFieldNameToActual[goName] = strings.TrimPrefix(pr.SchemaHandler.InPathToExPath[goRootName+"."+goName], actualRootName+".")
+		}
+	}
+
 	// Create Series and DataFrame (Parquet file returns the data type)
 	seriess := []dataframe.Series{}
 
@@ -82,33 +122,27 @@ func LoadFromParquet(ctx context.Context, src source.ParquetFile, opts ...Parque
 		goName := field.Name
 		actualName := goFieldNameToActual[goName]
 
+		if scan != nil && scan.Columns != nil && !contains(scan.Columns, actualName) {
+			continue
+		}
+
 		// Check if goName is a time series
 		_, ok := goTimeFields[goName]
 		if ok {
 			seriess = append(seriess, dataframe.NewSeriesTime(actualName, init))
 		} else {
-			kind := field.Type.Kind()
-			if kind == reflect.Ptr {
-				kind = field.Type.Elem().Kind()
-			}
-
-			switch kind {
-			case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				seriess = append(seriess, dataframe.NewSeriesInt64(actualName, init))
-			case reflect.Float32, reflect.Float64:
-				seriess = append(seriess, dataframe.NewSeriesFloat64(actualName, init))
-			case reflect.String:
-				seriess = append(seriess, dataframe.NewSeriesString(actualName, init))
-			default:
-				panic("unrecognized data type for column: " + actualName)
-			}
+			seriess = append(seriess, parquetSeries(pr.SchemaHandler, actualName, goRootName+"."+goName, field.Type, init))
 		}
 	}
 
 	// Create the dataframe
 	df := dataframe.NewDataFrame(seriess...)
 
+	idxs := map[string]int{}
+	for idx, name := range df.Names(dataframe.DontLock) {
+		idxs[name] = idx
+	}
+
 	// Load data to Series
 	vs := reflect.MakeSlice(reflect.SliceOf(pr.ObjType), 1, 1)
 	res := reflect.New(vs.Type())
@@ -128,6 +162,9 @@ func LoadFromParquet(ctx context.Context, src source.ParquetFile, opts ...Parque
 		for j := 0; j < row.NumField(); j++ { // iterate over fields in row
 			goName := pr.ObjType.Field(j).Name
 			name := goFieldNameToActual[goName]
+			if _, exists := idxs[name]; !exists {
+				continue
+			}
 			field := row.Field(j)
 			val := field.Interface()
 
@@ -164,98 +201,23 @@ func LoadFromParquet(ctx context.Context, src source.ParquetFile, opts ...Parque
 
 				}
 			} else {
-				switch v := val.(type) {
-				case *string:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = *v
-					}
-				case string:
-					insertVals[name] = v
-				case *float32:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = float64(*v)
-					}
-				case float32:
-					insertVals[name] = float64(v)
-				case *float64:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = *v
-					}
-				case float64:
-					insertVals[name] = v
-				case *uint8:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = int64(*v)
-					}
-				case uint8:
-					insertVals[name] = int64(v)
-				case *uint16:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = int64(*v)
-					}
-				case uint16:
-					insertVals[name] = int64(v)
-				case *uint32:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = int64(*v)
-					}
-				case uint32:
-					insertVals[name] = int64(v)
-				case *uint64:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = *v
-					}
-				case uint64:
-					insertVals[name] = v
-				case *int8:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = int64(*v)
-					}
-				case int8:
-					insertVals[name] = int64(v)
-				case *int16:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = int64(*v)
-					}
-				case int16:
-					insertVals[name] = int64(v)
-				case *int32:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = int64(*v)
-					}
-				case int32:
-					insertVals[name] = int64(v)
-				case *int64:
-					if v == nil {
-						insertVals[name] = nil
-					} else {
-						insertVals[name] = *v
-					}
-				case int64:
-					insertVals[name] = v
-				default:
-					panic("unrecognized data type for column: " + name)
-				}
+				insertVals[name] = parquetValue(pr.SchemaHandler, goRootName+"."+goName, name, val)
+			}
+		}
+
+		if scan != nil && scan.Filter != nil {
+			vals := make(map[interface{}]interface{}, 2*len(insertVals))
+			for name, v := range insertVals {
+				vals[idxs[name]] = v
+				vals[name] = v
+			}
+
+			fa, err := scan.Filter(vals, i, nRows)
+			if err != nil {
+				return nil, err
+			}
+			if fa == dataframe.DROP {
+				continue
 			}
 		}
 
@@ -264,3 +226,172 @@ func LoadFromParquet(ctx context.Context, src source.ParquetFile, opts ...Parque
 
 	return df, nil
 }
+
+// parquetSeries creates a Series for a column with the Go type t. inPath is the internal path of the column.
+// A LIST column is stored in a SeriesList and a nested group is stored in a SeriesStruct.
+func parquetSeries(sh *schema.SchemaHandler, name, inPath string, t reflect.Type, init *dataframe.SeriesInit) dataframe.Series {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return dataframe.NewSeriesInt64(name, init)
+	case reflect.Float32, reflect.Float64:
+		return dataframe.NewSeriesFloat64(name, init)
+	case reflect.String:
+		return dataframe.NewSeriesString(name, init)
+	case reflect.Slice:
+		// LIST column
+		child := parquetSeries(sh, "", parquetElementPath(sh, inPath), t.Elem(), nil)
+		return dataframe.NewSeriesList(name, child, init)
+	case reflect.Struct:
+		// Nested group
+		fields := make([]dataframe.Series, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			fieldPath := inPath + "." + t.Field(i).Name
+			fields = append(fields, parquetSeries(sh, parquetExName(sh, fieldPath), fieldPath, t.Field(i).Type, init))
+		}
+		return dataframe.NewSeriesStruct(name, nil, fields...)
+	default:
+		panic("unrecognized data type for column: " + name)
+	}
+}
+
+// parquetElementPath returns the internal path of the elements of a LIST column.
+func parquetElementPath(sh *schema.SchemaHandler, inPath string) string {
+	if _, exists := sh.MapIndex[inPath+".List.Element"]; exists {
+		return inPath + ".List.Element"
+	}
+	return inPath // repeated field
+}
+
+// parquetExName returns the actual name of the field with the given internal path.
+func parquetExName(sh *schema.SchemaHandler, inPath string) string {
+	return sh.Infos[sh.MapIndex[inPath]].ExName
+}
+
+// parquetValue converts a value read from a parquet file so that it can be stored in a Series.
+// The elements of a LIST column and the fields of a nested group are converted individually.
+func parquetValue(sh *schema.SchemaHandler, inPath, name string, val interface{}) interface{} {
+	switch v := val.(type) {
+	case *bool:
+		if v == nil {
+			return nil
+		}
+		return int64(dataframe.B(*v))
+	case bool:
+		return int64(dataframe.B(v))
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case string:
+		return v
+	case *float32:
+		if v == nil {
+			return nil
+		}
+		return float64(*v)
+	case float32:
+		return float64(v)
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case float64:
+		return v
+	case *uint8:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case uint8:
+		return int64(v)
+	case *uint16:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case uint16:
+		return int64(v)
+	case *uint32:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case uint32:
+		return int64(v)
+	case *uint64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case uint64:
+		return v
+	case *int8:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case int8:
+		return int64(v)
+	case *int16:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case int16:
+		return int64(v)
+	case *int32:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case int32:
+		return int64(v)
+	case *int64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case int64:
+		return v
+	default:
+		rv := reflect.ValueOf(val)
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return nil
+			}
+			rv = rv.Elem()
+		}
+
+		switch rv.Kind() {
+		case reflect.Slice:
+			// LIST column
+			if rv.IsNil() {
+				return nil
+			}
+
+			elemPath := parquetElementPath(sh, inPath)
+			list := make([]interface{}, 0, rv.Len())
+			for i := 0; i < rv.Len(); i++ {
+				list = append(list, parquetValue(sh, elemPath, name, rv.Index(i).Interface()))
+			}
+			return list
+		case reflect.Struct:
+			// Nested group
+			m := make(map[string]interface{}, rv.NumField())
+			for i := 0; i < rv.NumField(); i++ {
+				fieldPath := inPath + "." + rv.Type().Field(i).Name
+				m[parquetExName(sh, fieldPath)] = parquetValue(sh, fieldPath, name, rv.Field(i).Interface())
+			}
+			return m
+		default:
+			panic("unrecognized data type for column: " + name)
+		}
+	}
+}
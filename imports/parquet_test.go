@@ -0,0 +1,118 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+type parquetAddress struct {
+	City     string `parquet:"name=city, type=UTF8"`
+	Postcode *int64 `parquet:"name=postcode, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+type parquetRecord struct {
+	ID        int64            `parquet:"name=id, type=INT64"`
+	Tags      []string         `parquet:"name=tags, type=LIST, valuetype=UTF8"`
+	Scores    []float64        `parquet:"name=scores, type=LIST, valuetype=DOUBLE"`
+	Address   parquetAddress   `parquet:"name=address"`
+	Addresses []parquetAddress `parquet:"name=addresses, type=LIST"`
+}
+
+type parquetBoolRecord struct {
+	Active *bool  `parquet:"name=active, type=BOOLEAN, repetitiontype=OPTIONAL"`
+	Flags  []bool `parquet:"name=flags, type=LIST, valuetype=BOOLEAN"`
+}
+
+func writeParquet(t *testing.T, obj interface{}, recs ...interface{}) source.ParquetFile {
+	fw, _ := buffer.NewBufferFile(nil)
+
+	pw, err := writer.NewParquetWriter(fw, obj, 1)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	for _, rec := range recs {
+		if err := pw.Write(rec); err != nil {
+			t.Fatalf("error encountered: %s\n", err)
+		}
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	fr, _ := buffer.NewBufferFile(fw.(buffer.BufferFile).Bytes())
+	return fr
+}
+
+func TestLoadFromParquetNested(t *testing.T) {
+	ctx := context.Background()
+
+	postcode := int64(2000)
+
+	fr := writeParquet(t, new(parquetRecord),
+		parquetRecord{1, []string{"a", "b"}, []float64{1.5}, parquetAddress{"Sydney", &postcode}, []parquetAddress{{"Perth", nil}}},
+		parquetRecord{2, nil, []float64{}, parquetAddress{"Melbourne", nil}, nil},
+		parquetRecord{3, []string{"c"}, []float64{2, 3}, parquetAddress{"Adelaide", nil}, []parquetAddress{{"Hobart", &postcode}, {"Darwin", nil}}},
+	)
+
+	df, err := LoadFromParquet(ctx, fr)
+	assert.NoError(t, err)
+
+	address := func(city string, postcode interface{}) map[string]interface{} {
+		return map[string]interface{}{"city": city, "postcode": postcode}
+	}
+
+	expected := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("id", nil, 1, 2, 3),
+		dataframe.NewSeriesList("tags", dataframe.NewSeriesString("", nil), nil, []string{"a", "b"}, []string{}, []string{"c"}),
+		dataframe.NewSeriesList("scores", dataframe.NewSeriesFloat64("", nil), nil, []float64{1.5}, []float64{}, []float64{2, 3}),
+		dataframe.NewSeriesStruct("address", nil,
+			dataframe.NewSeriesString("city", nil, "Sydney", "Melbourne", "Adelaide"),
+			dataframe.NewSeriesInt64("postcode", nil, 2000, nil, nil),
+		),
+		dataframe.NewSeriesList("addresses", dataframe.NewSeriesStruct("", nil,
+			dataframe.NewSeriesString("city", nil),
+			dataframe.NewSeriesInt64("postcode", nil),
+		), nil,
+			[]interface{}{address("Perth", nil)},
+			[]interface{}{},
+			[]interface{}{address("Hobart", int64(2000)), address("Darwin", nil)},
+		),
+	)
+
+	assert.Equal(t, expected.Names(), df.Names())
+	assertEqualDS(t, expected, df)
+}
+
+func TestLoadFromParquetBool(t *testing.T) {
+	ctx := context.Background()
+
+	active := true
+
+	fr := writeParquet(t, new(parquetBoolRecord),
+		parquetBoolRecord{&active, []bool{true, false}},
+		parquetBoolRecord{nil, []bool{}},
+		parquetBoolRecord{&[]bool{false}[0], []bool{false}},
+	)
+
+	df, err := LoadFromParquet(ctx, fr)
+	assert.NoError(t, err)
+
+	expected := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("active", nil, 1, nil, 0),
+		dataframe.NewSeriesList("flags", dataframe.NewSeriesInt64("", nil), nil, []int64{1, 0}, []int64{}, []int64{0}),
+	)
+
+	assert.Equal(t, expected.Names(), df.Names())
+	assertEqualDS(t, expected, df)
+}
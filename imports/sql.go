@@ -7,6 +7,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -51,6 +53,11 @@ type rows interface {
 	Scan(dest ...interface{}) error
 }
 
+var (
+	sqlDaysRegex  = regexp.MustCompile(`^([-+]?\d+) days? ?(.*)$`)
+	sqlClockRegex = regexp.MustCompile(`^([-+])?(\d+):([0-5]\d):([0-5]\d)(?:\.(\d{1,9}))?$`)
+)
+
 // SQLLoadOptions is likely to change.
 type SQLLoadOptions struct {
 
@@ -65,6 +72,8 @@ type SQLLoadOptions struct {
 	// The key must be the case-sensitive column name.
 	// The value for a given key must be of the data type of the data.
 	// eg. For a string use "". For an int64 use int64(0). What is relevant is the data type and not the value itself.
+	// A time.Duration can be stored as a duration string (see dataframe.ParseDuration), a MySQL TIME (eg. "-01:30:00.5"),
+	// a PostgreSQL INTERVAL (eg. "1 day 02:00:00") or as nanoseconds.
 	//
 	// NOTE: A custom Series must implement NewSerieser interface and be able to interpret strings to work.
 	DictateDataType map[string]interface{}
@@ -76,6 +85,10 @@ type SQLLoadOptions struct {
 	//
 	// See: https://godoc.org/github.com/rocketlaunchr/mysql-go
 	Query string
+
+	// Location is used to interpret times that don't contain time zone information.
+	// It is also set as the Location of every SeriesTime created. When nil, UTC is assumed.
+	Location *time.Location
 }
 
 // LoadFromSQL will load data from a sql database.
@@ -87,6 +100,7 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 	var (
 		init     *dataframe.SeriesInit
 		database Database
+		loc      *time.Location
 		row      int
 		df       *dataframe.DataFrame
 	)
@@ -103,6 +117,8 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 		if database != PostgreSQL && database != MySQL {
 			return nil, errors.New("invalid database")
 		}
+
+		loc = options.Location
 	}
 
 	var (
@@ -162,6 +178,8 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 					seriess = append(seriess, dataframe.NewSeriesString(name, init))
 				case time.Time:
 					seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+				case time.Duration:
+					seriess = append(seriess, dataframe.NewSeriesDuration(name, init))
 				case dataframe.NewSerieser:
 					seriess = append(seriess, T.NewSeries(name, init))
 				case Converter:
@@ -197,6 +215,7 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 		}
 
 	}
+	setLocation(seriess, loc)
 	df = dataframe.NewDataFrame(seriess...)
 
 	for rows.Next() {
@@ -261,7 +280,7 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 							layout = "2006-01-02 15:04:05"
 						}
 
-						t, err := time.Parse(layout, *val)
+						t, err := parseTime(layout, *val, loc)
 						if err != nil {
 							// Assume unix timestamp
 							sec, err := strconv.ParseInt(*val, 10, 64)
@@ -271,6 +290,12 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 							t = time.Unix(sec, 0)
 						}
 						insertVals[fieldName] = t
+					case time.Duration:
+						d, err := parseSQLDuration(*val)
+						if err != nil {
+							return nil, fmt.Errorf("can't force string: %s to time.Duration. row: %d field: %s", *val, row-1, fieldName)
+						}
+						insertVals[fieldName] = d
 					case dataframe.NewSerieser:
 						insertVals[fieldName] = *val
 					case Converter:
@@ -316,7 +341,7 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 					layout = "2006-01-02 15:04:05"
 				}
 
-				t, err := time.Parse(layout, *val)
+				t, err := parseTime(layout, *val, loc)
 				if err != nil {
 					// Assume unix timestamp
 					sec, err := strconv.ParseInt(*val, 10, 64)
@@ -360,3 +385,67 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 
 	return df, nil
 }
+
+// parseSQLDuration parses a duration stored in a database. s can be a duration string (see dataframe.ParseDuration),
+// a MySQL TIME or PostgreSQL INTERVAL in the format: [[-]N day[s] ][-]hh:mm:ss[.fffffffff] or nanoseconds.
+func parseSQLDuration(s string) (time.Duration, error) {
+	d, err := dataframe.ParseDuration(s)
+	if err == nil {
+		return d, nil
+	}
+
+	if d, ok := parseSQLClock(s); ok {
+		return d, nil
+	}
+
+	// Assume nanoseconds
+	ns, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ns), nil
+}
+
+// parseSQLClock parses the time formats used by MySQL TIME and PostgreSQL INTERVAL columns.
+func parseSQLClock(s string) (time.Duration, bool) {
+	var days int64
+
+	if matches := sqlDaysRegex.FindStringSubmatch(s); matches != nil {
+		var err error
+		days, err = strconv.ParseInt(matches[1], 10, 64)
+		if err != nil || days > math.MaxInt64/int64(24*time.Hour) || days < math.MinInt64/int64(24*time.Hour) {
+			return 0, false
+		}
+
+		s = matches[2]
+		if s == "" {
+			return time.Duration(days) * 24 * time.Hour, true
+		}
+	}
+
+	matches := sqlClockRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, false
+	}
+
+	h, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil || h > math.MaxInt64/int64(time.Hour)-24*days-1 {
+		return 0, false
+	}
+	m, _ := strconv.ParseInt(matches[3], 10, 64)
+	sec, _ := strconv.ParseInt(matches[4], 10, 64)
+
+	var frac int64
+	if matches[5] != "" {
+		frac, _ = strconv.ParseInt(matches[5], 10, 64)
+		for i := len(matches[5]); i < 9; i++ {
+			frac = frac * 10
+		}
+	}
+
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second + time.Duration(frac)
+	if matches[1] == "-" {
+		d = -d
+	}
+	return time.Duration(days)*24*time.Hour + d, true
+}
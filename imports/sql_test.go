@@ -0,0 +1,101 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+// execRecorder records the arguments of the statements executed by ExportToSQL.
+type execRecorder struct {
+	args []interface{}
+}
+
+func (e *execRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.args = append(e.args, args...)
+	return nil, nil
+}
+
+func TestSQLDuration(t *testing.T) {
+	ctx := context.Background()
+
+	durations := []time.Duration{
+		0,
+		90 * time.Minute,
+		-90 * time.Minute,
+		838*time.Hour + 59*time.Minute + 59*time.Second,
+		-(time.Hour + 500*time.Millisecond),
+		2*time.Second + 123456*time.Microsecond,
+	}
+
+	// Export to MySQL and PostgreSQL and load the values back
+	for _, database := range []exports.Database{exports.MySQL, exports.PostgreSQL} {
+		rec := &execRecorder{}
+
+		df := dataframe.NewDataFrame(dataframe.NewSeriesDuration("d", nil))
+		for _, d := range durations {
+			df.Append(nil, d)
+		}
+
+		err := exports.ExportToSQL(ctx, rec, df, "table", exports.SQLExportOptions{Database: database})
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v got: %v", nil, err)
+		}
+
+		if len(rec.args) != len(durations) {
+			t.Fatalf("wrong val: expected: %v actual: %v", len(durations), len(rec.args))
+		}
+
+		for i, arg := range rec.args {
+			val := *arg.(*string)
+
+			d, err := parseSQLDuration(val)
+			if err != nil {
+				t.Errorf("%d: %s: wrong err: expected: %v got: %v", database, val, nil, err)
+				continue
+			}
+
+			if d != durations[i] {
+				t.Errorf("%d: %s: wrong val: expected: %v actual: %v", database, val, durations[i], d)
+			}
+		}
+	}
+
+	// PostgreSQL INTERVAL text and nanoseconds
+	tests := []struct {
+		in       string
+		expected time.Duration
+	}{
+		{"01:30:00", 90 * time.Minute},
+		{"-01:30:00.25", -(90*time.Minute + 250*time.Millisecond)},
+		{"1 day", 24 * time.Hour},
+		{"2 days 01:00:00", 49 * time.Hour},
+		{"-1 days +02:00:00", -22 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"1000", 1000},
+	}
+
+	for i, tc := range tests {
+		d, err := parseSQLDuration(tc.in)
+		if err != nil {
+			t.Errorf("%d: wrong err: expected: %v got: %v", i, nil, err)
+			continue
+		}
+
+		if d != tc.expected {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, d)
+		}
+	}
+
+	for i, in := range []string{"01:60:00", "1:2", "3 mons", "abc"} {
+		if _, err := parseSQLDuration(in); err == nil {
+			t.Errorf("%d: wrong err: expected: %v got: %v", i, "error", err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+//go:build go1.23
+// +build go1.23
+
+package dataframe
+
+import (
+	"iter"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// seq returns an iterator over the rows of a Series (or DataFrame) that honours the InitialRow, Step
+// and DontReadLock options. nRows and val are called while the read lock is held.
+func seq[V any](lock *sync.RWMutex, nRows func() int, val func(row int) V, opts ...ValuesOptions) iter.Seq2[int, V] {
+
+	var (
+		initial      int
+		step         int = 1
+		dontReadLock bool
+	)
+
+	if len(opts) > 0 {
+		dontReadLock = opts[0].DontReadLock
+		initial = opts[0].InitialRow
+		if opts[0].Step != 0 {
+			step = opts[0].Step
+		}
+	}
+
+	return func(yield func(int, V) bool) {
+		row := initial
+		first := true
+
+		for {
+			if !dontReadLock {
+				lock.RLock()
+			}
+
+			n := nRows()
+			if first && row < 0 {
+				row = n + row
+			}
+			first = false
+
+			if row > n-1 || row < 0 {
+				// Don't iterate further
+				if !dontReadLock {
+					lock.RUnlock()
+				}
+				return
+			}
+
+			v := val(row)
+
+			if !dontReadLock {
+				lock.RUnlock()
+			}
+
+			if !yield(row, v) {
+				return
+			}
+			row = row + step
+		}
+	}
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+//
+// Example:
+//
+//  for row, val := range s.All() {
+//     ...
+//  }
+//
+func (s *SeriesFloat64) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.Values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// Floats returns an iterator over the rows and values of the Series. Nil values are returned as NaN.
+func (s *SeriesFloat64) Floats(opts ...ValuesOptions) iter.Seq2[int, float64] {
+	return seq(&s.lock, func() int { return len(s.Values) }, func(row int) float64 { return s.Values[row] }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesInt64) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// Int64s returns an iterator over the rows and values of the Series. Nil values are returned as nil.
+//
+// NOTE: The returned pointers must not be used to modify the values.
+func (s *SeriesInt64) Int64s(opts ...ValuesOptions) iter.Seq2[int, *int64] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) *int64 { return s.values[row] }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesString) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// Strings returns an iterator over the rows and values of the Series. Nil values are returned as nil.
+//
+// NOTE: The returned pointers must not be used to modify the values.
+func (s *SeriesString) Strings(opts ...ValuesOptions) iter.Seq2[int, *string] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) *string { return s.values[row] }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesTime) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.Values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// Times returns an iterator over the rows and values of the Series. Nil values are returned as nil.
+//
+// NOTE: The returned pointers must not be used to modify the values.
+func (s *SeriesTime) Times(opts ...ValuesOptions) iter.Seq2[int, *time.Time] {
+	return seq(&s.lock, func() int { return len(s.Values) }, func(row int) *time.Time { return s.Values[row] }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesDuration) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// Durations returns an iterator over the rows and values of the Series. Nil values are returned as nil.
+//
+// NOTE: The returned pointers must not be used to modify the values.
+func (s *SeriesDuration) Durations(opts ...ValuesOptions) iter.Seq2[int, *time.Duration] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) *time.Duration { return s.values[row] }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesMixed) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesGeneric) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesList) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.values) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesStruct) All(opts ...ValuesOptions) iter.Seq2[int, interface{}] {
+	return seq(&s.lock, func() int { return len(s.nils) }, func(row int) interface{} { return s.Value(row, dontLock) }, opts...)
+}
+
+// Rows returns an iterator over the rows of the DataFrame. The values of each row are ordered by Series.
+// Unlike ValuesIterator, a map is not allocated for each row.
+//
+// NOTE: The slice is reused between iterations, so it must be copied if it is retained.
+//
+// Example:
+//
+//  for row, vals := range df.Rows() {
+//     ...
+//  }
+//
+func (df *DataFrame) Rows(opts ...ValuesOptions) iter.Seq2[int, []interface{}] {
+	var buf []interface{}
+
+	return seq(&df.lock, func() int { return df.n }, func(row int) []interface{} {
+		buf = buf[:0]
+		for _, aSeries := range df.Series {
+			buf = append(buf, aSeries.Value(row, dontLock))
+		}
+		return buf
+	}, opts...)
+}
+
+// StructRows returns an iterator that decodes each row of df into a struct of type T.
+// Series are matched to fields using the same rules as FromStructs. See RowInto for more details.
+// If a value can't be stored in the corresponding field, a RowError is yielded and the iteration stops.
+//
+// NOTE: The struct is reused between iterations, so it must be copied if it is retained.
+//
+// Example:
+//
+//  for order, err := range dataframe.StructRows[Order](df) {
+//     if err != nil {
+//        return err
+//     }
+//     ...
+//  }
+//
+func StructRows[T any](df *DataFrame, opts ...ValuesOptions) iter.Seq2[*T, error] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic("T must be a struct")
+	}
+
+	fields := structFields(t)
+	dst := new(T)
+	rv := reflect.ValueOf(dst).Elem()
+	zero := reflect.Zero(t)
+
+	rows := seq(&df.lock, func() int { return df.n }, func(row int) error {
+		rv.Set(zero)
+		return df.rowInto(row, rv, fields)
+	}, opts...)
+
+	return func(yield func(*T, error) bool) {
+		for _, err := range rows {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(dst, nil) {
+				return
+			}
+		}
+	}
+}
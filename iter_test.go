@@ -0,0 +1,126 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+//go:build go1.23
+// +build go1.23
+
+package dataframe
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSeriesIterators(t *testing.T) {
+
+	sf := NewSeriesFloat64("float", nil, 1.0, nil, 3.0, 4.0)
+
+	rows := []int{}
+	vals := []interface{}{}
+	for row, val := range sf.All() {
+		rows = append(rows, row)
+		vals = append(vals, val)
+	}
+
+	if !cmp.Equal(rows, []int{0, 1, 2, 3}) || !cmp.Equal(vals, []interface{}{1.0, nil, 3.0, 4.0}) {
+		t.Errorf("wrong vals: %v %v", rows, vals)
+	}
+
+	// Step and negative InitialRow
+	rows = []int{}
+	for row := range sf.All(ValuesOptions{InitialRow: -1, Step: -2}) {
+		rows = append(rows, row)
+	}
+
+	if !cmp.Equal(rows, []int{3, 1}) {
+		t.Errorf("wrong rows: expected: %v actual: %v", []int{3, 1}, rows)
+	}
+
+	// Early termination
+	var count int
+	for _, f := range sf.Floats() {
+		if math.IsNaN(f) {
+			break
+		}
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("wrong count: expected: %v actual: %v", 1, count)
+	}
+
+	si := NewSeriesInt64("int", nil, 1, nil, 3)
+
+	ints := []interface{}{}
+	for _, i := range si.Int64s(ValuesOptions{DontReadLock: true}) {
+		if i == nil {
+			ints = append(ints, nil)
+		} else {
+			ints = append(ints, *i)
+		}
+	}
+
+	if !cmp.Equal(ints, []interface{}{int64(1), nil, int64(3)}) {
+		t.Errorf("wrong vals: %v", ints)
+	}
+}
+
+func TestDataFrameIterators(t *testing.T) {
+
+	df := NewDataFrame(
+		NewSeriesInt64("id", nil, 1, 2),
+		NewSeriesString("customer", nil, "alice", nil),
+	)
+
+	out := [][]interface{}{}
+	for _, vals := range df.Rows() {
+		out = append(out, append([]interface{}{}, vals...))
+	}
+
+	expected := [][]interface{}{{int64(1), "alice"}, {int64(2), nil}}
+	if !cmp.Equal(out, expected) {
+		t.Errorf("wrong rows: expected: %v actual: %v", expected, out)
+	}
+
+	type Order struct {
+		ID       int    `dataframe:"id"`
+		Customer string `dataframe:"customer"`
+	}
+
+	orders := []Order{}
+	for o, err := range StructRows[Order](df) {
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+		}
+		orders = append(orders, *o)
+	}
+
+	if !cmp.Equal(orders, []Order{{1, "alice"}, {2, ""}}) {
+		t.Errorf("wrong structs: %v", orders)
+	}
+
+	// Unconvertible value
+	type BadOrder struct {
+		ID       int `dataframe:"id"`
+		Customer int `dataframe:"customer"`
+	}
+
+	count := 0
+	var iterErr error
+	for o, err := range StructRows[BadOrder](df) {
+		count++
+		if err != nil {
+			if o != nil {
+				t.Errorf("wrong val: expected: %v actual: %v", nil, o)
+			}
+			iterErr = err
+		}
+	}
+
+	var rowErr *RowError
+	if count != 1 || !errors.As(iterErr, &rowErr) || rowErr.Row != 0 {
+		t.Errorf("wrong err: expected: %v actual: %v (after %d rows)", "RowError for row 0", iterErr, count)
+	}
+}
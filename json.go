@@ -0,0 +1,172 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// seriesJSON is the JSON representation of a built-in Series.
+type seriesJSON struct {
+	Name      string          `json:"name"`
+	Type      string          `json:"type"`
+	Formatter string          `json:"formatter,omitempty"`
+	Layout    string          `json:"layout,omitempty"`
+	Location  string          `json:"location,omitempty"`
+	Offset    *int            `json:"offset,omitempty"` // offset of Location (in seconds east of UTC)
+	Child     *childJSON      `json:"child,omitempty"`
+	Fields    []childJSON     `json:"fields,omitempty"`
+	Values    json.RawMessage `json:"values"`
+}
+
+func marshalSeriesJSON(sj seriesJSON, vals interface{}) ([]byte, error) {
+	v, err := json.Marshal(vals)
+	if err != nil {
+		return nil, err
+	}
+	sj.Values = v
+	return json.Marshal(sj)
+}
+
+// unmarshalSeriesJSON decodes data into sj and the values into vals. An error is returned if
+// the type does not match typ.
+func unmarshalSeriesJSON(data []byte, typ string, vals interface{}) (seriesJSON, error) {
+	var sj seriesJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return sj, err
+	}
+
+	if sj.Type != typ {
+		return sj, fmt.Errorf("wrong type: expected: %s got: %s", typ, sj.Type)
+	}
+
+	if vals != nil && len(sj.Values) > 0 {
+		if err := json.Unmarshal(sj.Values, vals); err != nil {
+			return sj, err
+		}
+	}
+	return sj, nil
+}
+
+type dataFrameJSON struct {
+	Schema []schemaJSON      `json:"schema"`
+	Data   []json.RawMessage `json:"data"`
+}
+
+type schemaJSON struct {
+	Name   string `json:"name"`
+	Series string `json:"series"` // Name used to register the Series
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The output is self-describing so that the DataFrame can be decoded with identical types.
+// The schema contains the name of each Series and the name that its type was registered under
+// (see RegisterSeries). The data contains the JSON representation of each Series.
+//
+// Example:
+//
+//  {
+//    "schema": [{"name": "price", "series": "dataframe.SeriesFloat64"}],
+//    "data": [{"name": "price", "type": "float64", "values": [1.5, null]}]
+//  }
+//
+// Each Series must implement json.Marshaler and be registered using RegisterSeries.
+func (df *DataFrame) MarshalJSON() ([]byte, error) {
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	out := dataFrameJSON{
+		Schema: make([]schemaJSON, 0, len(df.Series)),
+		Data:   make([]json.RawMessage, 0, len(df.Series)),
+	}
+
+	for _, s := range df.Series {
+		name := s.Name()
+
+		typ, exists := registeredName(s)
+		if !exists {
+			return nil, &SeriesError{Series: name, Err: fmt.Errorf("%T is not registered", s)}
+		}
+
+		m, ok := s.(json.Marshaler)
+		if !ok {
+			return nil, &SeriesError{Series: name, Err: fmt.Errorf("%T does not implement json.Marshaler", s)}
+		}
+
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return nil, &SeriesError{Series: name, Err: err}
+		}
+
+		out.Schema = append(out.Schema, schemaJSON{Name: name, Series: typ})
+		out.Data = append(out.Data, data)
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The existing Series of the DataFrame are replaced.
+//
+// NOTE: If the DataFrame already contains a Series with the same name and type, it is used as a template
+// for the decoded Series. This is required to decode a SeriesGeneric since its concrete type can't be
+// determined from the JSON. The template is not modified.
+func (df *DataFrame) UnmarshalJSON(data []byte) error {
+	var in dataFrameJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	if len(in.Schema) != len(in.Data) {
+		return fmt.Errorf("schema contains %d series but data contains %d", len(in.Schema), len(in.Data))
+	}
+
+	// Templates
+	df.lock.RLock()
+	existing := map[string]Series{}
+	for _, s := range df.Series {
+		existing[s.Name()] = s
+	}
+	df.lock.RUnlock()
+
+	seriess := make([]Series, 0, len(in.Schema))
+
+	for idx, sc := range in.Schema {
+		s, exists := newRegistered(sc.Series)
+		if !exists {
+			return &SeriesError{Series: sc.Name, Err: fmt.Errorf("%s is not registered", sc.Series)}
+		}
+
+		if tmpl, exists := existing[sc.Name]; exists {
+			if typ, _ := registeredName(tmpl); typ == sc.Series {
+				s = tmpl.Copy()
+			}
+		}
+
+		u, ok := s.(json.Unmarshaler)
+		if !ok {
+			return &SeriesError{Series: sc.Name, Err: fmt.Errorf("%s does not implement json.Unmarshaler", sc.Series)}
+		}
+
+		if err := u.UnmarshalJSON(in.Data[idx]); err != nil {
+			return &SeriesError{Series: sc.Name, Err: err}
+		}
+
+		seriess = append(seriess, s)
+	}
+
+	n, err := checkSeries(seriess)
+	if err != nil {
+		return err
+	}
+
+	df.lock.Lock()
+	defer df.lock.Unlock()
+
+	df.Series = seriess
+	df.n = n
+
+	return nil
+}
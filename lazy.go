@@ -0,0 +1,620 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ScanOptions informs a LazySource what data is required by a LazyFrame.
+type ScanOptions struct {
+
+	// Columns contains the names of the Series that are required.
+	// When nil, all Series are required.
+	Columns []string
+
+	// Filter, when not nil, determines which rows are required.
+	Filter FilterDataFrameFn
+
+	// FilterColumns contains the names of the Series used by Filter.
+	// When nil, Filter may use any Series.
+	FilterColumns []string
+}
+
+// LazySource is a source of data for a LazyFrame.
+type LazySource interface {
+
+	// Scan loads the data required by a LazyFrame. The returned DataFrame must contain the Series in Columns
+	// (or all Series when Columns is nil) and may contain others. A LazySource should avoid loading rows that are
+	// dropped by Filter. If Filter was applied, filtered must be true. Otherwise the LazyFrame will apply it.
+	Scan(ctx context.Context, opts ScanOptions) (df *DataFrame, filtered bool, err error)
+}
+
+// AggregateFn is used to combine the values of a group.
+type AggregateFn func(vals []float64) float64
+
+var (
+	// AggSum returns the sum of the values.
+	AggSum AggregateFn = func(vals []float64) float64 {
+		var sum float64
+		for _, v := range vals {
+			sum = sum + v
+		}
+		return sum
+	}
+
+	// AggMean returns the mean of the values. NaN is returned if there are no values.
+	AggMean AggregateFn = func(vals []float64) float64 {
+		if len(vals) == 0 {
+			return math.NaN()
+		}
+		return AggSum(vals) / float64(len(vals))
+	}
+
+	// AggMin returns the smallest value. NaN is returned if there are no values.
+	AggMin AggregateFn = func(vals []float64) float64 {
+		if len(vals) == 0 {
+			return math.NaN()
+		}
+		min := vals[0]
+		for _, v := range vals[1:] {
+			min = math.Min(min, v)
+		}
+		return min
+	}
+
+	// AggMax returns the largest value. NaN is returned if there are no values.
+	AggMax AggregateFn = func(vals []float64) float64 {
+		if len(vals) == 0 {
+			return math.NaN()
+		}
+		max := vals[0]
+		for _, v := range vals[1:] {
+			max = math.Max(max, v)
+		}
+		return max
+	}
+
+	// AggCount returns the number of (non-nil) values.
+	AggCount AggregateFn = func(vals []float64) float64 {
+		return float64(len(vals))
+	}
+)
+
+// Aggregation describes how the values of a Series are combined for each group.
+type Aggregation struct {
+
+	// Series is the name of the Series to aggregate.
+	// Its values must be float64, int64 or time.Duration (or nil).
+	Series string
+
+	// Name is the name of the resulting Series. When blank, Series is used.
+	Name string
+
+	// Fn combines the non-nil values of a group. The result is stored in a SeriesFloat64.
+	Fn AggregateFn
+}
+
+func (a Aggregation) name() string {
+	if a.Name == "" {
+		return a.Series
+	}
+	return a.Name
+}
+
+// lazyOp is an operation of a LazyFrame's logical plan.
+type lazyOp interface {
+	String() string
+}
+
+type lazyFilter struct {
+	fns  []FilterDataFrameFn
+	cols []string // nil means any Series may be used
+}
+
+type lazySelect struct {
+	cols []string
+}
+
+type lazyGroupBy struct {
+	keys []string
+	aggs []Aggregation
+}
+
+func (f *lazyFilter) String() string {
+	out := "FILTER"
+	if f.cols != nil {
+		out = out + fmt.Sprintf(" %v", f.cols)
+	}
+	if len(f.fns) > 1 {
+		out = out + fmt.Sprintf(" (%d fused)", len(f.fns))
+	}
+	return out
+}
+
+func (s *lazySelect) String() string {
+	return fmt.Sprintf("SELECT %v", s.cols)
+}
+
+func (g *lazyGroupBy) String() string {
+	aggs := []string{}
+	for _, a := range g.aggs {
+		aggs = append(aggs, a.name())
+	}
+	return fmt.Sprintf("GROUPBY %v AGG %v", g.keys, aggs)
+}
+
+// fn returns a FilterDataFrameFn that keeps a row only if all the fused functions keep it.
+func (f *lazyFilter) fn() FilterDataFrameFn {
+	if len(f.fns) == 1 {
+		return f.fns[0]
+	}
+
+	return func(vals map[interface{}]interface{}, row, nRows int) (FilterAction, error) {
+		for _, fn := range f.fns {
+			fa, err := fn(vals, row, nRows)
+			if err != nil {
+				return DROP, err
+			}
+			if fa == DROP {
+				return DROP, nil
+			}
+		}
+		return KEEP, nil
+	}
+}
+
+// LazyFrame builds a logical plan of operations that is only executed when Collect is called.
+// Before execution, the plan is optimized: consecutive filters are fused, filters are moved as early as possible
+// and both the filters and the required Series are pushed down into the LazySource. This allows sources such as
+// files to avoid loading data that is not required.
+//
+// A LazyFrame is immutable. Each operation returns a new LazyFrame.
+//
+// Example:
+//
+//  df, err := imports.ScanCSV(r, opts).
+//     Filter(func(vals map[interface{}]interface{}, row, nRows int) (dataframe.FilterAction, error) {
+//        if vals["country"] == "AU" {
+//           return dataframe.KEEP, nil
+//        }
+//        return dataframe.DROP, nil
+//     }, "country").
+//     GroupBy([]string{"city"}, dataframe.Aggregation{Series: "sales", Fn: dataframe.AggSum}).
+//     Collect(ctx)
+//
+type LazyFrame struct {
+	src LazySource
+	ops []lazyOp
+}
+
+// NewLazyFrame creates a LazyFrame that loads data from src.
+func NewLazyFrame(src LazySource) *LazyFrame {
+	if src == nil {
+		panic("src is required")
+	}
+	return &LazyFrame{src: src}
+}
+
+// Lazy returns a LazyFrame with df as the source. The data is not copied until Collect is called.
+func (df *DataFrame) Lazy() *LazyFrame {
+	return NewLazyFrame(&dataFrameSource{df: df})
+}
+
+func (lf *LazyFrame) with(op lazyOp) *LazyFrame {
+	ops := make([]lazyOp, 0, len(lf.ops)+1)
+	ops = append(ops, lf.ops...)
+	return &LazyFrame{src: lf.src, ops: append(ops, op)}
+}
+
+// Filter adds an operation that drops rows for which fn returns DROP.
+// cols must contain the names of all the Series used by fn. If cols is not provided,
+// fn is assumed to use every Series, which prevents unused Series from being pruned.
+//
+// NOTE: Since filters can be reordered and pushed down into the LazySource, fn should access
+// vals using Series names (not indices) and should not rely on row and nRows (nRows may be -1 if unknown).
+func (lf *LazyFrame) Filter(fn FilterDataFrameFn, cols ...string) *LazyFrame {
+	if fn == nil {
+		panic("fn is required")
+	}
+
+	f := &lazyFilter{fns: []FilterDataFrameFn{fn}}
+	if len(cols) > 0 {
+		f.cols = append([]string{}, cols...)
+	}
+	return lf.with(f)
+}
+
+// Select adds an operation that keeps only the Series in cols (in the order provided).
+func (lf *LazyFrame) Select(cols ...string) *LazyFrame {
+	return lf.with(&lazySelect{cols: append([]string{}, cols...)})
+}
+
+// GroupBy adds an operation that groups rows with identical values for the keys Series and combines the values
+// of each group using aggs. The result contains the keys Series followed by a SeriesFloat64 for each Aggregation.
+// Groups are ordered by their first appearance.
+func (lf *LazyFrame) GroupBy(keys []string, aggs ...Aggregation) *LazyFrame {
+	if len(keys) == 0 {
+		panic("keys are required")
+	}
+
+	for _, a := range aggs {
+		if a.Fn == nil {
+			panic("Fn is required")
+		}
+	}
+
+	return lf.with(&lazyGroupBy{keys: append([]string{}, keys...), aggs: append([]Aggregation{}, aggs...)})
+}
+
+// plan is an optimized logical plan.
+type plan struct {
+	scan ScanOptions
+	ops  []lazyOp
+}
+
+// optimize fuses and reorders the operations and determines what must be loaded by the LazySource.
+func (lf *LazyFrame) optimize() plan {
+
+	// Predicate pushdown
+	ops := []lazyOp{}
+	for _, op := range lf.ops {
+		f, ok := op.(*lazyFilter)
+		if !ok {
+			ops = append(ops, op)
+			continue
+		}
+
+		// Move the filter before operations that don't affect the Series it uses
+		f = &lazyFilter{fns: append([]FilterDataFrameFn{}, f.fns...), cols: f.cols}
+
+		idx := len(ops)
+		for idx > 0 {
+			prev := ops[idx-1]
+			if _, ok := prev.(*lazySelect); ok {
+				idx--
+				continue
+			}
+			if g, ok := prev.(*lazyGroupBy); ok && f.cols != nil && subset(f.cols, g.keys) {
+				idx--
+				continue
+			}
+			break
+		}
+
+		// Fuse with a preceding filter
+		if idx > 0 {
+			if pf, ok := ops[idx-1].(*lazyFilter); ok {
+				fused := &lazyFilter{fns: append(append([]FilterDataFrameFn{}, pf.fns...), f.fns...)}
+				if pf.cols != nil && f.cols != nil {
+					fused.cols = union(pf.cols, f.cols)
+				}
+				ops[idx-1] = fused
+				continue
+			}
+		}
+
+		ops = append(ops[:idx], append([]lazyOp{f}, ops[idx:]...)...)
+	}
+
+	var p plan
+
+	// Push the leading filter into the source
+	if len(ops) > 0 {
+		if f, ok := ops[0].(*lazyFilter); ok {
+			p.scan.Filter = f.fn()
+			p.scan.FilterColumns = f.cols
+			ops = ops[1:]
+		}
+	}
+	p.ops = ops
+
+	// Projection pushdown (nil means all Series are required)
+	var need []string
+	for i := len(ops) - 1; i >= 0; i-- {
+		switch op := ops[i].(type) {
+		case *lazySelect:
+			// Only the selected Series are available to later operations
+			need = op.cols
+		case *lazyGroupBy:
+			need = append([]string{}, op.keys...)
+			for _, a := range op.aggs {
+				need = union(need, []string{a.Series})
+			}
+		case *lazyFilter:
+			if need != nil {
+				if op.cols == nil {
+					need = nil
+				} else {
+					need = union(need, op.cols)
+				}
+			}
+		}
+	}
+
+	if need != nil && p.scan.Filter != nil {
+		if p.scan.FilterColumns == nil {
+			need = nil
+		} else {
+			need = union(need, p.scan.FilterColumns)
+		}
+	}
+	p.scan.Columns = need
+
+	return p
+}
+
+// Explain returns a description of the optimized plan.
+func (lf *LazyFrame) Explain() string {
+	p := lf.optimize()
+
+	lines := []string{}
+	for i := len(p.ops) - 1; i >= 0; i-- {
+		lines = append(lines, p.ops[i].String())
+	}
+
+	src := fmt.Sprintf("%T", lf.src)
+	if s, ok := lf.src.(fmt.Stringer); ok {
+		src = s.String()
+	}
+
+	scan := "SCAN " + src
+	if p.scan.Columns != nil {
+		scan = scan + fmt.Sprintf(" COLUMNS %v", p.scan.Columns)
+	}
+	if p.scan.Filter != nil {
+		scan = scan + " FILTER"
+		if p.scan.FilterColumns != nil {
+			scan = scan + fmt.Sprintf(" %v", p.scan.FilterColumns)
+		}
+	}
+	lines = append(lines, scan)
+
+	for i := range lines {
+		lines[i] = strings.Repeat("  ", i) + lines[i]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Collect optimizes and executes the plan.
+func (lf *LazyFrame) Collect(ctx context.Context) (*DataFrame, error) {
+	p := lf.optimize()
+
+	df, filtered, err := lf.src.Scan(ctx, p.scan)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.scan.Filter != nil && !filtered {
+		if df, err = filterDataFrame(ctx, df, p.scan.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, op := range p.ops {
+		switch op := op.(type) {
+		case *lazyFilter:
+			df, err = filterDataFrame(ctx, df, op.fn())
+		case *lazySelect:
+			df, err = selectSeries(df, op.cols)
+		case *lazyGroupBy:
+			df, err = groupBy(ctx, df, op.keys, op.aggs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return df, nil
+}
+
+// selectSeries returns a DataFrame containing the Series in cols. The Series are not copied.
+func selectSeries(df *DataFrame, cols []string) (*DataFrame, error) {
+	seriess := make([]Series, 0, len(cols))
+	for _, name := range cols {
+		idx, err := df.NameToColumn(name, dontLock)
+		if err != nil {
+			return nil, err
+		}
+		seriess = append(seriess, df.Series[idx])
+	}
+	return NewDataFrame(seriess...), nil
+}
+
+// groupKey returns a string that uniquely identifies the values of the key Series of a row.
+// Times representing the same instant have the same key.
+func groupKey(vals []interface{}) string {
+	parts := make([]string, 0, len(vals))
+	for _, val := range vals {
+		if t, ok := val.(time.Time); ok {
+			val = t.UTC()
+		}
+		parts = append(parts, fmt.Sprintf("%T:%v", val, val))
+	}
+	return strings.Join(parts, "\x1f")
+}
+
+// aggValue converts val into a float64 for the purposes of aggregation.
+func aggValue(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case time.Duration:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("can't aggregate %T", val)
+	}
+}
+
+func groupBy(ctx context.Context, df *DataFrame, keys []string, aggs []Aggregation) (*DataFrame, error) {
+
+	keySeries := make([]Series, 0, len(keys))
+	for _, name := range keys {
+		idx, err := df.NameToColumn(name, dontLock)
+		if err != nil {
+			return nil, err
+		}
+		keySeries = append(keySeries, df.Series[idx])
+	}
+
+	aggSeries := make([]Series, 0, len(aggs))
+	for _, a := range aggs {
+		idx, err := df.NameToColumn(a.Series, dontLock)
+		if err != nil {
+			return nil, err
+		}
+		aggSeries = append(aggSeries, df.Series[idx])
+	}
+
+	var (
+		groups  = map[string]int{}
+		rows    = [][]int{} // rows of each group
+		keyVals = [][]interface{}{}
+	)
+
+	nRows := df.NRows(dontLock)
+	for row := 0; row < nRows; row++ {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		vals := make([]interface{}, 0, len(keySeries))
+		for _, s := range keySeries {
+			vals = append(vals, s.Value(row, dontLock))
+		}
+		key := groupKey(vals)
+
+		g, exists := groups[key]
+		if !exists {
+			g = len(rows)
+			groups[key] = g
+			rows = append(rows, []int{})
+			keyVals = append(keyVals, vals)
+		}
+		rows[g] = append(rows[g], row)
+	}
+
+	init := &SeriesInit{Capacity: len(rows)}
+
+	seriess := make([]Series, 0, len(keys)+len(aggs))
+	for i, s := range keySeries {
+		var ns Series
+		if nser, ok := s.(NewSerieser); ok {
+			ns = nser.NewSeries(keys[i], init)
+		} else {
+			ns = NewSeriesMixed(keys[i], init)
+		}
+		for _, vals := range keyVals {
+			ns.Append(vals[i], dontLock)
+		}
+		seriess = append(seriess, ns)
+	}
+
+	for i, a := range aggs {
+		ns := NewSeriesFloat64(a.name(), init)
+		for _, grp := range rows {
+
+			// Cancel operation
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			vals := make([]float64, 0, len(grp))
+			for _, row := range grp {
+				val := aggSeries[i].Value(row, dontLock)
+				if val == nil {
+					continue
+				}
+				f, err := aggValue(val)
+				if err != nil {
+					return nil, &RowError{Row: row, Err: &SeriesError{Series: a.Series, Err: err}}
+				}
+				vals = append(vals, f)
+			}
+			ns.Append(a.Fn(vals), dontLock)
+		}
+		seriess = append(seriess, ns)
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+// dataFrameSource is a LazySource for an in-memory DataFrame.
+type dataFrameSource struct {
+	df *DataFrame
+}
+
+func (s *dataFrameSource) String() string {
+	return "DataFrame"
+}
+
+func (s *dataFrameSource) Scan(ctx context.Context, opts ScanOptions) (*DataFrame, bool, error) {
+	s.df.lock.RLock()
+	defer s.df.lock.RUnlock()
+
+	seriess := []Series{}
+	if opts.Columns == nil {
+		seriess = append(seriess, s.df.Series...)
+	} else {
+		for _, name := range opts.Columns {
+			idx, err := s.df.NameToColumn(name, dontLock)
+			if err != nil {
+				return nil, false, err
+			}
+			seriess = append(seriess, s.df.Series[idx])
+		}
+	}
+	df := NewDataFrame(seriess...)
+
+	if opts.Filter != nil {
+		// Filter creates new Series
+		out, err := filterDataFrame(ctx, df, opts.Filter, FilterOptions{DontLock: true})
+		if err != nil {
+			return nil, false, err
+		}
+		return out, true, nil
+	}
+
+	// Avoid copying (where possible)
+	if df.NRows(dontLock) > 0 {
+		return df.View(Range{}), false, nil
+	}
+	return df.Copy(), false, nil
+}
+
+// subset returns true if all the elements of a are in b.
+func subset(a, b []string) bool {
+	for _, x := range a {
+		var found bool
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// union returns the elements of a followed by the elements of b that are not in a.
+func union(a, b []string) []string {
+	out := append([]string{}, a...)
+	for _, x := range b {
+		if !subset([]string{x}, out) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// FlattenOptions modifies the behavior of Flatten and Unflatten.
+type FlattenOptions struct {
+
+	// Separator is used to join the name of a SeriesStruct with the names of its fields.
+	// The default is ".".
+	Separator string
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+func (o FlattenOptions) separator() string {
+	if o.Separator == "" {
+		return "."
+	}
+	return o.Separator
+}
+
+// Flatten returns a new DataFrame where every SeriesStruct is replaced by the Series of its fields (recursively).
+// The name of each field is prefixed with the name of the SeriesStruct and the separator.
+// The values of the fields of a nil struct are nil. The Series are copied.
+//
+// Example:
+//
+//  // "address" (struct{city string, postcode int64}) => "address.city", "address.postcode"
+//  flat, err := dataframe.Flatten(ctx, df)
+//
+func Flatten(ctx context.Context, df *DataFrame, opts ...FlattenOptions) (*DataFrame, error) {
+
+	var options FlattenOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if !options.DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	seriess := []Series{}
+	for _, s := range df.Series {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		seriess = append(seriess, flattenSeries(s, "", options.separator())...)
+	}
+
+	if _, err := checkSeries(seriess); err != nil {
+		return nil, err
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+func flattenSeries(s Series, prefix, sep string) []Series {
+	ss, ok := s.(*SeriesStruct)
+	if !ok {
+		cs := s.Copy()
+		cs.Rename(prefix+s.Name(dontLock), dontLock)
+		return []Series{cs}
+	}
+
+	out := []Series{}
+	for _, f := range ss.fields {
+		out = append(out, flattenSeries(f, prefix+ss.name+sep, sep)...)
+	}
+	return out
+}
+
+// Unflatten returns a new DataFrame where the Series with names containing the separator are grouped into
+// a SeriesStruct (recursively). It is the inverse of Flatten. The Series are copied.
+//
+// NOTE: A nil struct can't be distinguished from a struct whose fields are all nil. The rows of the SeriesStruct
+// are never nil.
+//
+// Example:
+//
+//  // "address.city", "address.postcode" => "address" (struct{city string, postcode int64})
+//  nested, err := dataframe.Unflatten(ctx, df)
+//
+func Unflatten(ctx context.Context, df *DataFrame, opts ...FlattenOptions) (*DataFrame, error) {
+
+	var options FlattenOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if !options.DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	sep := options.separator()
+
+	// Build tree
+	root := &nestedNode{}
+	for _, s := range df.Series {
+		name := s.Name(dontLock)
+
+		n := root
+		for _, part := range strings.Split(name, sep) {
+			if n.series != nil {
+				return nil, &SeriesError{Series: name, Err: errors.New("name clashes with another series")}
+			}
+			n = n.child(part)
+		}
+
+		if n.series != nil || len(n.children) > 0 {
+			return nil, &SeriesError{Series: name, Err: errors.New("name clashes with another series")}
+		}
+		n.series = s
+	}
+
+	seriess := make([]Series, 0, len(root.children))
+	for _, n := range root.children {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		seriess = append(seriess, n.build())
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+type nestedNode struct {
+	name     string
+	series   Series // nil for a SeriesStruct
+	children []*nestedNode
+}
+
+func (n *nestedNode) child(name string) *nestedNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+
+	c := &nestedNode{name: name}
+	n.children = append(n.children, c)
+	return c
+}
+
+func (n *nestedNode) build() Series {
+	if n.series != nil {
+		cs := n.series.Copy()
+		cs.Rename(n.name, dontLock)
+		return cs
+	}
+
+	fields := make([]Series, 0, len(n.children))
+	for _, c := range n.children {
+		fields = append(fields, c.build())
+	}
+	return NewSeriesStruct(n.name, nil, fields...)
+}
@@ -0,0 +1,381 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// Normalization determines how the values of a Crosstab are normalized.
+type Normalization int
+
+const (
+	// NormalizeNone will not normalize the values.
+	NormalizeNone Normalization = 0
+
+	// NormalizeAll will divide each value by the sum of all the cells.
+	NormalizeAll Normalization = 1
+
+	// NormalizeRows will divide each value by the sum of the cells in its row.
+	NormalizeRows Normalization = 2
+
+	// NormalizeColumns will divide each value by the sum of the cells in its column.
+	NormalizeColumns Normalization = 3
+)
+
+// CrosstabOptions configures how Crosstab should behave.
+type CrosstabOptions struct {
+
+	// Values can be set to aggregate the values of a Series instead of counting frequencies.
+	// The Series must be convertible to a SeriesFloat64 and have the same number of rows as the
+	// row and column Series. AggFn is required when Values is set.
+	Values dataframe.Series
+
+	// AggFn is used to aggregate the non-nil values of Values that belong to each cell.
+	// The values passed to AggFn never contain NaN values.
+	//
+	// Example:
+	//
+	//  opts := pandas.CrosstabOptions{
+	//     Values: sales,
+	//     AggFn:  dataframe.AggMean,
+	//  }
+	//
+	AggFn dataframe.AggregateFn
+
+	// Margins can be set to add row and column totals.
+	Margins bool
+
+	// MarginsName is the name of the row and column containing the totals.
+	// The default is "All".
+	MarginsName string
+
+	// Normalize can be set to normalize the values.
+	Normalize Normalization
+
+	// IncludeNil can be set to treat nil values in the row and column Series as a category.
+	// By default, those rows are ignored.
+	IncludeNil bool
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// category is a distinct value found in a Series.
+type category struct {
+	key string
+	val interface{}
+}
+
+// categorize returns the distinct values of s (sorted) and the position of each row's value
+// within those values. Rows that must be ignored are given a position of -1.
+func categorize(ctx context.Context, s dataframe.Series, includeNil bool) ([]category, []int, error) {
+
+	nRows := s.NRows(dataframe.DontLock)
+
+	cats := []category{}
+	lookup := map[string]int{}
+	pos := make([]int, 0, nRows)
+
+	for row := 0; row < nRows; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		val := s.Value(row, dataframe.DontLock)
+		if val == nil && !includeNil {
+			pos = append(pos, -1)
+			continue
+		}
+
+		key := s.ValueString(row, dataframe.DontLock)
+		idx, exists := lookup[key]
+		if !exists {
+			idx = len(cats)
+			lookup[key] = idx
+			cats = append(cats, category{key: key, val: val})
+		}
+		pos = append(pos, idx)
+	}
+
+	// Sort the categories. If the Series can't compare values, the order of appearance is retained.
+	order := make([]int, len(cats))
+	for i := range order {
+		order[i] = i
+	}
+
+	func() {
+		defer func() {
+			if x := recover(); x != nil {
+				for i := range order {
+					order[i] = i
+				}
+			}
+		}()
+		sort.SliceStable(order, func(i, j int) bool {
+			return s.IsLessThanFunc(cats[order[i]].val, cats[order[j]].val) && !s.IsEqualFunc(cats[order[i]].val, cats[order[j]].val)
+		})
+	}()
+
+	sorted := make([]category, len(cats))
+	newPos := make([]int, len(cats))
+	for i, idx := range order {
+		sorted[i] = cats[idx]
+		newPos[idx] = i
+	}
+
+	for row, idx := range pos {
+		if idx != -1 {
+			pos[row] = newPos[idx]
+		}
+	}
+
+	return sorted, pos, nil
+}
+
+// Crosstab computes a frequency table (contingency table) of two Series.
+// The distinct values of rowSeries become the rows and the distinct values of colSeries become the columns.
+// By default, each cell contains the number of rows containing that combination of values.
+// If the Values option is set, each cell instead contains the aggregation of the corresponding values.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.crosstab.html
+func Crosstab(ctx context.Context, rowSeries, colSeries dataframe.Series, opts ...CrosstabOptions) (*dataframe.DataFrame, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, CrosstabOptions{})
+	}
+
+	if opts[0].MarginsName == "" {
+		opts[0].MarginsName = "All"
+	}
+
+	if opts[0].Values != nil && opts[0].AggFn == nil {
+		return nil, errors.New("AggFn is required when Values is set")
+	}
+
+	if !opts[0].DontLock {
+		defer readLock(rowSeries)()
+		if colSeries != rowSeries {
+			defer readLock(colSeries)()
+		}
+		if vs := opts[0].Values; vs != nil && vs != rowSeries && vs != colSeries {
+			defer readLock(vs)()
+		}
+	}
+
+	nRows := rowSeries.NRows(dataframe.DontLock)
+	if colSeries.NRows(dataframe.DontLock) != nRows {
+		return nil, errors.New("different number of rows in series")
+	}
+
+	// Values to aggregate
+	var vals []float64
+	if opts[0].Values != nil {
+		if opts[0].Values.NRows(dataframe.DontLock) != nRows {
+			return nil, errors.New("different number of rows in series")
+		}
+
+		switch vs := opts[0].Values.(type) {
+		case *dataframe.SeriesFloat64:
+			vals = vs.Values
+		case dataframe.ToSeriesFloat64:
+			sf, err := vs.ToSeriesFloat64(ctx, false)
+			if err != nil && sf == nil {
+				return nil, err
+			}
+			vals = sf.Values
+		default:
+			return nil, errors.New("Values must be convertible to SeriesFloat64")
+		}
+	}
+
+	rowCats, rowPos, err := categorize(ctx, rowSeries, opts[0].IncludeNil)
+	if err != nil {
+		return nil, err
+	}
+
+	colCats, colPos, err := categorize(ctx, colSeries, opts[0].IncludeNil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the values of each cell, row and column
+	nR, nC := len(rowCats), len(colCats)
+
+	cells := make([][][]float64, nR)
+	for i := range cells {
+		cells[i] = make([][]float64, nC)
+	}
+	rowGroups := make([][]float64, nR)
+	colGroups := make([][]float64, nC)
+	var allGroup []float64
+
+	counts := make([][]int, nR)
+	for i := range counts {
+		counts[i] = make([]int, nC)
+	}
+
+	for row := 0; row < nRows; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		r, c := rowPos[row], colPos[row]
+		if r == -1 || c == -1 {
+			continue
+		}
+
+		counts[r][c]++
+
+		if vals != nil {
+			v := vals[row]
+			if math.IsNaN(v) {
+				continue
+			}
+			cells[r][c] = append(cells[r][c], v)
+			rowGroups[r] = append(rowGroups[r], v)
+			colGroups[c] = append(colGroups[c], v)
+			allGroup = append(allGroup, v)
+		}
+	}
+
+	aggregate := func(group []float64, count int) float64 {
+		if vals == nil {
+			return float64(count)
+		}
+		if len(group) == 0 {
+			return math.NaN()
+		}
+		return opts[0].AggFn(group)
+	}
+
+	// Compute the table. The last row and column contain the margins.
+	table := make([][]float64, nR+1)
+	for r := range table {
+		table[r] = make([]float64, nC+1)
+	}
+
+	var allCount int
+	rowCounts := make([]int, nR)
+	colCounts := make([]int, nC)
+
+	for r := 0; r < nR; r++ {
+		for c := 0; c < nC; c++ {
+			table[r][c] = aggregate(cells[r][c], counts[r][c])
+			rowCounts[r] = rowCounts[r] + counts[r][c]
+			colCounts[c] = colCounts[c] + counts[r][c]
+			allCount = allCount + counts[r][c]
+		}
+	}
+
+	for r := 0; r < nR; r++ {
+		table[r][nC] = aggregate(rowGroups[r], rowCounts[r])
+	}
+	for c := 0; c < nC; c++ {
+		table[nR][c] = aggregate(colGroups[c], colCounts[c])
+	}
+	table[nR][nC] = aggregate(allGroup, allCount)
+
+	// Normalize
+	if opts[0].Normalize != NormalizeNone {
+		sum := func(fs ...float64) float64 {
+			var total float64
+			for _, f := range fs {
+				if !math.IsNaN(f) {
+					total = total + f
+				}
+			}
+			return total
+		}
+
+		rowSums := make([]float64, nR+1)
+		colSums := make([]float64, nC+1)
+		var allSum float64
+
+		for r := 0; r < nR; r++ {
+			rowSums[r] = sum(table[r][:nC]...)
+			allSum = allSum + rowSums[r]
+		}
+		for c := 0; c < nC; c++ {
+			for r := 0; r < nR; r++ {
+				colSums[c] = sum(colSums[c], table[r][c])
+			}
+		}
+		rowSums[nR] = allSum
+		colSums[nC] = allSum
+
+		for r := range table {
+			for c := range table[r] {
+				switch opts[0].Normalize {
+				case NormalizeAll:
+					table[r][c] = table[r][c] / allSum
+				case NormalizeRows:
+					table[r][c] = table[r][c] / rowSums[r]
+				case NormalizeColumns:
+					table[r][c] = table[r][c] / colSums[c]
+				}
+			}
+		}
+	}
+
+	// Create the DataFrame
+	outRows, outCols := nR, nC
+	if opts[0].Margins {
+		outRows++
+		outCols++
+	}
+
+	index := dataframe.NewSeriesString(rowSeries.Name(dataframe.DontLock), &dataframe.SeriesInit{Capacity: outRows})
+	for _, cat := range rowCats {
+		index.Append(cat.key, dataframe.DontLock)
+	}
+	if opts[0].Margins {
+		index.Append(opts[0].MarginsName, dataframe.DontLock)
+	}
+
+	unique := map[string]struct{}{index.Name(dataframe.DontLock): {}}
+	seriess := []dataframe.Series{index}
+
+	useInt := vals == nil && opts[0].Normalize == NormalizeNone
+
+	for c := 0; c < outCols; c++ {
+		var name string
+		if c < nC {
+			name = colCats[c].key
+		} else {
+			name = opts[0].MarginsName
+		}
+
+		if _, exists := unique[name]; exists {
+			return nil, errors.New("names of series must be unique: " + name)
+		}
+		unique[name] = struct{}{}
+
+		var s dataframe.Series
+		if useInt {
+			s = dataframe.NewSeriesInt64(name, &dataframe.SeriesInit{Capacity: outRows})
+		} else {
+			s = dataframe.NewSeriesFloat64(name, &dataframe.SeriesInit{Capacity: outRows})
+		}
+
+		for r := 0; r < outRows; r++ {
+			v := table[r][c]
+			if math.IsNaN(v) {
+				s.Append(nil, dataframe.DontLock)
+			} else if useInt {
+				s.Append(int64(v), dataframe.DontLock)
+			} else {
+				s.Append(v, dataframe.DontLock)
+			}
+		}
+
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
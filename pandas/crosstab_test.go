@@ -0,0 +1,123 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+var ctx = context.Background()
+
+func TestCrosstab(t *testing.T) {
+
+	gender := dataframe.NewSeriesString("gender", nil, "m", "f", "m", "f", "m", nil)
+	smoker := dataframe.NewSeriesString("smoker", nil, "y", "n", "n", "n", "y", "y")
+	sales := dataframe.NewSeriesFloat64("sales", nil, 1, 2, 3, 4, 5, 6)
+
+	sum := func(vals []float64) float64 {
+		var total float64
+		for _, v := range vals {
+			total = total + v
+		}
+		return total
+	}
+
+	tests := []struct {
+		name     string
+		opts     CrosstabOptions
+		expected *dataframe.DataFrame
+	}{
+		{
+			"frequencies",
+			CrosstabOptions{},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "f", "m"),
+				dataframe.NewSeriesInt64("n", nil, 2, 1),
+				dataframe.NewSeriesInt64("y", nil, 0, 2),
+			),
+		},
+		{
+			"margins",
+			CrosstabOptions{Margins: true, MarginsName: "Total"},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "f", "m", "Total"),
+				dataframe.NewSeriesInt64("n", nil, 2, 1, 3),
+				dataframe.NewSeriesInt64("y", nil, 0, 2, 2),
+				dataframe.NewSeriesInt64("Total", nil, 2, 3, 5),
+			),
+		},
+		{
+			"include nil",
+			CrosstabOptions{IncludeNil: true},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "NaN", "f", "m"),
+				dataframe.NewSeriesInt64("n", nil, 0, 2, 1),
+				dataframe.NewSeriesInt64("y", nil, 1, 0, 2),
+			),
+		},
+		{
+			"normalize all",
+			CrosstabOptions{Normalize: NormalizeAll},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "f", "m"),
+				dataframe.NewSeriesFloat64("n", nil, 2.0/5, 1.0/5),
+				dataframe.NewSeriesFloat64("y", nil, 0.0, 2.0/5),
+			),
+		},
+		{
+			"normalize rows with margins",
+			CrosstabOptions{Normalize: NormalizeRows, Margins: true},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "f", "m", "All"),
+				dataframe.NewSeriesFloat64("n", nil, 1.0, 1.0/3, 3.0/5),
+				dataframe.NewSeriesFloat64("y", nil, 0.0, 2.0/3, 2.0/5),
+				dataframe.NewSeriesFloat64("All", nil, 1.0, 1.0, 1.0),
+			),
+		},
+		{
+			"normalize columns",
+			CrosstabOptions{Normalize: NormalizeColumns},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "f", "m"),
+				dataframe.NewSeriesFloat64("n", nil, 2.0/3, 1.0/3),
+				dataframe.NewSeriesFloat64("y", nil, 0.0, 1.0),
+			),
+		},
+		{
+			"values",
+			CrosstabOptions{Values: sales, AggFn: sum, Margins: true},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("gender", nil, "f", "m", "All"),
+				dataframe.NewSeriesFloat64("n", nil, 6.0, 3.0, 9.0),
+				dataframe.NewSeriesFloat64("y", nil, nil, 6.0, 6.0),
+				dataframe.NewSeriesFloat64("All", nil, 6.0, 9.0, 15.0),
+			),
+		},
+	}
+
+	for i, tc := range tests {
+		df, err := Crosstab(ctx, gender, smoker, tc.opts)
+		if err != nil {
+			t.Errorf("%d: %s: wrong err: expected: %v actual: %v", i, tc.name, nil, err)
+			continue
+		}
+
+		eq, err := tc.expected.IsEqual(ctx, df)
+		if err != nil {
+			t.Errorf("%d: %s: wrong err: expected: %v actual: %v", i, tc.name, nil, err)
+		}
+
+		if !eq {
+			t.Errorf("%d: %s: wrong val: expected: %v actual: %v", i, tc.name, tc.expected.Table(), df.Table())
+		}
+	}
+
+	// AggFn is required when Values is set
+	_, err := Crosstab(ctx, gender, smoker, CrosstabOptions{Values: sales})
+	if err == nil {
+		t.Errorf("wrong err: expected: %v actual: %v", "error", err)
+	}
+}
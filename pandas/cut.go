@@ -0,0 +1,256 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+
+	"gonum.org/v1/gonum/stat"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// CutOptions configures how Cut should behave.
+type CutOptions struct {
+
+	// IncludeLowest can be set so that the first bin includes its left edge.
+	// It only applies when right is true.
+	IncludeLowest bool
+
+	// Precision sets the number of decimal places used for the bin edges in the default labels.
+	// When nil, the smallest number of decimal places necessary to represent the edges is used.
+	Precision *int
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// QCutOptions configures how QCut should behave.
+type QCutOptions struct {
+
+	// Labels sets the label of each bin. The number of labels must equal q.
+	// When nil, the labels describe the interval of each bin.
+	Labels []string
+
+	// Precision sets the number of decimal places used for the bin edges in the default labels.
+	// When nil, the smallest number of decimal places necessary to represent the edges is used.
+	Precision *int
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// readLock locks s for reading and returns a function that unlocks it.
+// Series that can't be locked for reading are locked for writing.
+func readLock(s dataframe.Series) func() {
+	if rl, ok := s.(dataframe.RLocker); ok {
+		rl.RLock()
+		return rl.RUnlock
+	}
+	s.Lock()
+	return s.Unlock
+}
+
+// binValues returns the values of s as float64. NaN represents a nil value.
+func binValues(ctx context.Context, s dataframe.Series) ([]float64, error) {
+	switch vs := s.(type) {
+	case *dataframe.SeriesFloat64:
+		return vs.Values, nil
+	case dataframe.ToSeriesFloat64:
+		sf, err := vs.ToSeriesFloat64(ctx, false)
+		if err != nil && sf == nil {
+			return nil, err
+		}
+		return sf.Values, nil
+	default:
+		return nil, errors.New("s must be convertible to SeriesFloat64")
+	}
+}
+
+// binLabels generates a label for each bin describing its interval.
+func binLabels(bins []float64, right, includeLowest bool, precision *int) []string {
+	prec := -1
+	if precision != nil {
+		prec = *precision
+	}
+
+	labels := []string{}
+	for i := 0; i < len(bins)-1; i++ {
+		l := strconv.FormatFloat(bins[i], 'f', prec, 64)
+		r := strconv.FormatFloat(bins[i+1], 'f', prec, 64)
+
+		if right {
+			if i == 0 && includeLowest {
+				labels = append(labels, "["+l+", "+r+"]")
+			} else {
+				labels = append(labels, "("+l+", "+r+"]")
+			}
+		} else {
+			labels = append(labels, "["+l+", "+r+")")
+		}
+	}
+	return labels
+}
+
+// bin assigns each value to a bin and returns a SeriesString containing the label of the bin.
+// Values that don't fall into any bin are given a nil value.
+func bin(ctx context.Context, name string, vals, bins []float64, labels []string, right, includeLowest bool) (*dataframe.SeriesString, error) {
+
+	ss := dataframe.NewSeriesString(name, &dataframe.SeriesInit{Capacity: len(vals)})
+
+	for _, v := range vals {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if math.IsNaN(v) {
+			ss.Append(nil, dataframe.DontLock)
+			continue
+		}
+
+		var idx int
+		if right {
+			// bins[idx-1] < v <= bins[idx]
+			idx = sort.SearchFloat64s(bins, v)
+			if idx == 0 && includeLowest && v == bins[0] {
+				idx = 1
+			}
+		} else {
+			// bins[idx-1] <= v < bins[idx]
+			idx = sort.Search(len(bins), func(i int) bool { return bins[i] > v })
+		}
+
+		if idx == 0 || idx == len(bins) {
+			// Out of range
+			ss.Append(nil, dataframe.DontLock)
+			continue
+		}
+
+		ss.Append(labels[idx-1], dataframe.DontLock)
+	}
+
+	return ss, nil
+}
+
+// Cut assigns each value of s into discrete bins defined by the bin edges. The edges must be
+// monotonically increasing. When right is true, each bin includes its right edge (eg. (0, 10]),
+// otherwise each bin includes its left edge (eg. [0, 10)).
+//
+// The returned SeriesString contains the label of the bin each value belongs to. If labels is nil,
+// the labels describe the interval of each bin. Otherwise the number of labels must be one less than the number
+// of bin edges. Nil values and values outside the bins are given a nil value.
+//
+// s must be a SeriesFloat64, SeriesInt64 or another Series that can be converted to a SeriesFloat64.
+//
+// Example:
+//
+//  ages := dataframe.NewSeriesInt64("age", nil, 4, 17, 35, 72)
+//  bands, _ := pandas.Cut(ctx, ages, []float64{0, 18, 65, 120}, []string{"child", "adult", "senior"}, true)
+//
+//  fmt.Println(bands)
+//  // Output: age: [ child child adult senior ]
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.cut.html
+func Cut(ctx context.Context, s dataframe.Series, bins []float64, labels []string, right bool, opts ...CutOptions) (*dataframe.SeriesString, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, CutOptions{})
+	}
+
+	if len(bins) < 2 {
+		return nil, errors.New("at least 2 bin edges are required")
+	}
+
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			return nil, errors.New("bin edges must increase monotonically")
+		}
+	}
+
+	if labels == nil {
+		labels = binLabels(bins, right, opts[0].IncludeLowest, opts[0].Precision)
+	} else if len(labels) != len(bins)-1 {
+		return nil, errors.New("number of labels must be one less than the number of bin edges")
+	}
+
+	if !opts[0].DontLock {
+		defer readLock(s)()
+	}
+
+	vals, err := binValues(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return bin(ctx, s.Name(dataframe.DontLock), vals, bins, labels, right, opts[0].IncludeLowest)
+}
+
+// QCut assigns each value of s into q bins such that each bin contains (approximately) the same
+// number of values. The bin edges are determined by the quantiles of s. Each bin includes its right edge
+// and the first bin also includes its left edge.
+//
+// The returned SeriesString contains the label of the bin each value belongs to. Nil values are given a nil value.
+// An error is returned if the quantiles don't produce unique bin edges.
+//
+// s must be a SeriesFloat64, SeriesInt64 or another Series that can be converted to a SeriesFloat64.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.qcut.html
+func QCut(ctx context.Context, s dataframe.Series, q int, opts ...QCutOptions) (*dataframe.SeriesString, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, QCutOptions{})
+	}
+
+	if q < 1 {
+		return nil, errors.New("q must be at least 1")
+	}
+
+	if opts[0].Labels != nil && len(opts[0].Labels) != q {
+		return nil, errors.New("number of labels must equal q")
+	}
+
+	if !opts[0].DontLock {
+		defer readLock(s)()
+	}
+
+	vals, err := binValues(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Arrange values from lowest to highest
+	sorted := []float64{}
+	for _, v := range vals {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+
+	if len(sorted) == 0 {
+		return nil, dataframe.ErrNoRows
+	}
+	sort.Float64s(sorted)
+
+	bins := []float64{sorted[0]}
+	for i := 1; i < q; i++ {
+		bins = append(bins, stat.Quantile(float64(i)/float64(q), stat.LinInterp, sorted, nil))
+	}
+	bins = append(bins, sorted[len(sorted)-1])
+
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			return nil, errors.New("bin edges must be unique")
+		}
+	}
+
+	labels := opts[0].Labels
+	if labels == nil {
+		labels = binLabels(bins, true, true, opts[0].Precision)
+	}
+
+	return bin(ctx, s.Name(dataframe.DontLock), vals, bins, labels, true, true)
+}
@@ -0,0 +1,125 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestCut(t *testing.T) {
+
+	s := dataframe.NewSeriesFloat64("x", nil, 0, 1, 5, 10, nil, 11)
+
+	tests := []struct {
+		name     string
+		bins     []float64
+		labels   []string
+		right    bool
+		opts     CutOptions
+		expected *dataframe.SeriesString
+	}{
+		{
+			"right edge",
+			[]float64{0, 5, 10}, nil, true, CutOptions{},
+			dataframe.NewSeriesString("x", nil, nil, "(0, 5]", "(0, 5]", "(5, 10]", nil, nil),
+		},
+		{
+			"right edge include lowest",
+			[]float64{0, 5, 10}, nil, true, CutOptions{IncludeLowest: true},
+			dataframe.NewSeriesString("x", nil, "[0, 5]", "[0, 5]", "[0, 5]", "(5, 10]", nil, nil),
+		},
+		{
+			"left edge",
+			[]float64{0, 5, 10}, nil, false, CutOptions{},
+			dataframe.NewSeriesString("x", nil, "[0, 5)", "[0, 5)", "[5, 10)", nil, nil, nil),
+		},
+		{
+			"labels",
+			[]float64{0, 5, 12}, []string{"low", "high"}, false, CutOptions{},
+			dataframe.NewSeriesString("x", nil, "low", "low", "high", "high", nil, "high"),
+		},
+		{
+			"precision",
+			[]float64{0, 5, 12}, nil, true, CutOptions{Precision: &[]int{1}[0]},
+			dataframe.NewSeriesString("x", nil, nil, "(0.0, 5.0]", "(0.0, 5.0]", "(5.0, 12.0]", nil, "(5.0, 12.0]"),
+		},
+	}
+
+	for i, tc := range tests {
+		out, err := Cut(ctx, s, tc.bins, tc.labels, tc.right, tc.opts)
+		if err != nil {
+			t.Errorf("%d: %s: wrong err: expected: %v actual: %v", i, tc.name, nil, err)
+			continue
+		}
+
+		eq, _ := tc.expected.IsEqual(ctx, out)
+		if !eq {
+			t.Errorf("%d: %s: wrong val: expected: %v actual: %v", i, tc.name, tc.expected, out)
+		}
+	}
+
+	// Invalid bins and labels
+	errTests := []struct {
+		bins   []float64
+		labels []string
+	}{
+		{[]float64{0}, nil},
+		{[]float64{0, 5, 5}, nil},
+		{[]float64{0, 5, 10}, []string{"low"}},
+	}
+
+	for i, tc := range errTests {
+		_, err := Cut(ctx, s, tc.bins, tc.labels, true)
+		if err == nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, "error", err)
+		}
+	}
+}
+
+func TestQCut(t *testing.T) {
+
+	tests := []struct {
+		s        dataframe.Series
+		q        int
+		opts     QCutOptions
+		expected *dataframe.SeriesString
+	}{
+		{
+			dataframe.NewSeriesInt64("x", nil, 1, 2, 3, 4, nil, 5, 6, 7, 8),
+			2, QCutOptions{},
+			dataframe.NewSeriesString("x", nil, "[1, 4]", "[1, 4]", "[1, 4]", "[1, 4]", nil, "(4, 8]", "(4, 8]", "(4, 8]", "(4, 8]"),
+		},
+		{
+			dataframe.NewSeriesFloat64("x", nil, 8, nil, 1, 4, 5),
+			2, QCutOptions{Labels: []string{"low", "high"}},
+			dataframe.NewSeriesString("x", nil, "high", nil, "low", "low", "high"),
+		},
+	}
+
+	for i, tc := range tests {
+		out, err := QCut(ctx, tc.s, tc.q, tc.opts)
+		if err != nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, nil, err)
+			continue
+		}
+
+		eq, _ := tc.expected.IsEqual(ctx, out)
+		if !eq {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, out)
+		}
+	}
+
+	// Duplicate bin edges
+	_, err := QCut(ctx, dataframe.NewSeriesInt64("x", nil, 1, 1, 1, 1, 2), 4)
+	if err == nil {
+		t.Errorf("wrong err: expected: %v actual: %v", "error", err)
+	}
+
+	// Only nil values
+	_, err = QCut(ctx, dataframe.NewSeriesFloat64("x", nil, nil, nil), 2)
+	if err != dataframe.ErrNoRows {
+		t.Errorf("wrong err: expected: %v actual: %v", dataframe.ErrNoRows, err)
+	}
+}
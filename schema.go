@@ -0,0 +1,291 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+var (
+	// ErrSeriesMissing means that a Series described by the Schema does not exist.
+	ErrSeriesMissing = errors.New("series missing")
+
+	// ErrSeriesUnexpected means that a Series is not described by a strict Schema.
+	ErrSeriesUnexpected = errors.New("series unexpected")
+
+	// ErrWrongType means that a Series does not hold the type of data described by the Schema.
+	ErrWrongType = errors.New("wrong type")
+
+	// ErrNilValue means that a nil value was found in a Series that is not nullable.
+	ErrNilValue = errors.New("nil value")
+
+	// ErrDuplicateValue means that a value was repeated in a Series that must be unique.
+	ErrDuplicateValue = errors.New("duplicate value")
+
+	// ErrOutOfRange means that a value was less than Min or greater than Max.
+	ErrOutOfRange = errors.New("out of range")
+
+	// ErrNotInEnum means that a value was not one of the allowed values.
+	ErrNotInEnum = errors.New("not an allowed value")
+
+	// ErrNoMatch means that a value did not match the Regex.
+	ErrNoMatch = errors.New("does not match regex")
+)
+
+// SchemaField describes the expected properties of a Series.
+type SchemaField struct {
+
+	// Name is the name of the Series.
+	Name string
+
+	// Type is the type of data the Series holds. What is relevant is the data type and not the value itself.
+	// eg. For a SeriesString use "". For a SeriesInt64 use int64(0). For a SeriesInt64 containing bools use false.
+	// For a SeriesTime use time.Time{} and for a SeriesDuration use time.Duration(0).
+	// For a custom Series, use a Series that implements NewSerieser. Other types are assumed to be held by a SeriesGeneric.
+	// When nil, the Series can hold any type.
+	Type interface{}
+
+	// Nullable can be set to allow nil values.
+	Nullable bool
+
+	// Unique can be set to disallow repeated values. Nil values are ignored.
+	Unique bool
+
+	// Min sets the minimum allowed value (inclusive).
+	// The value will be interpreted the same way as values inserted into the Series.
+	Min interface{}
+
+	// Max sets the maximum allowed value (inclusive).
+	// The value will be interpreted the same way as values inserted into the Series.
+	Max interface{}
+
+	// Enum sets the allowed values.
+	// The values will be interpreted the same way as values inserted into the Series.
+	Enum []interface{}
+
+	// Regex, when set, must match the string representation of each value.
+	//
+	// See: ValueString
+	Regex *regexp.Regexp
+
+	// Check, when set, is called for each non-nil value. Returning an error means the value is invalid.
+	Check func(val interface{}) error
+}
+
+// Schema describes the expected structure of a DataFrame.
+//
+// Example:
+//
+//  schema := dataframe.Schema{
+//     Fields: []dataframe.SchemaField{
+//        {Name: "id", Type: int64(0), Unique: true},
+//        {Name: "price", Type: float64(0), Min: 0.0},
+//        {Name: "currency", Type: "", Enum: []interface{}{"AUD", "USD"}},
+//     },
+//  }
+//
+//  df, _ := imports.LoadFromCSV(ctx, r, imports.CSVLoadOptions{DictateDataType: schema.DictateDataType()})
+//
+//  if err := schema.Validate(ctx, df); err != nil {
+//     for _, e := range err.(*dataframe.ErrorCollection).Errors() {
+//        fmt.Println(e)
+//     }
+//  }
+//
+type Schema struct {
+
+	// Fields describes each Series.
+	Fields []SchemaField
+
+	// Strict can be set to disallow Series that are not described by Fields.
+	Strict bool
+}
+
+// DictateDataType returns the Type of each field. It can be used as the DictateDataType option
+// of the imports package so that importing and validation share a single definition.
+// Fields with a nil Type are not included. A Type of int is returned as int64(0).
+func (sc Schema) DictateDataType() map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, f := range sc.Fields {
+		switch f.Type.(type) {
+		case nil:
+		case int:
+			out[f.Name] = int64(0)
+		default:
+			out[f.Name] = f.Type
+		}
+	}
+	return out
+}
+
+// Validate checks that df conforms to the Schema. If it does not, an ErrorCollection is returned.
+// Problems with a particular row are reported as a RowError (wrapping a SeriesError).
+// Problems with an entire Series are reported as a SeriesError.
+//
+// NOTE: Validation of a Series stops if it does not hold the expected type.
+func (sc Schema) Validate(ctx context.Context, df *DataFrame, opts ...Options) error {
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	ec := NewErrorCollection()
+
+	fields := map[string]struct{}{}
+
+	for _, f := range sc.Fields {
+		fields[f.Name] = struct{}{}
+
+		idx, err := df.NameToColumn(f.Name, dontLock)
+		if err != nil {
+			ec.AddError(&SeriesError{Series: f.Name, Err: ErrSeriesMissing}, false)
+			continue
+		}
+
+		if err := f.validate(ctx, df.Series[idx], ec); err != nil {
+			return err
+		}
+	}
+
+	if sc.Strict {
+		for _, s := range df.Series {
+			name := s.Name(dontLock)
+			if _, exists := fields[name]; !exists {
+				ec.AddError(&SeriesError{Series: name, Err: ErrSeriesUnexpected}, false)
+			}
+		}
+	}
+
+	if !ec.IsNil(false) {
+		return ec
+	}
+
+	return nil
+}
+
+// isType returns true if s holds the type of data described by typ.
+func isType(s Series, typ interface{}) bool {
+	switch T := typ.(type) {
+	case nil:
+		return true
+	case float64:
+		_, ok := s.(*SeriesFloat64)
+		return ok
+	case int, int64, bool:
+		_, ok := s.(*SeriesInt64)
+		return ok
+	case string:
+		_, ok := s.(*SeriesString)
+		return ok
+	case time.Time:
+		_, ok := s.(*SeriesTime)
+		return ok
+	case time.Duration:
+		_, ok := s.(*SeriesDuration)
+		return ok
+	case NewSerieser:
+		return reflect.TypeOf(s) == reflect.TypeOf(T)
+	default:
+		return s.Type() == fmt.Sprintf("generic(%T)", typ)
+	}
+}
+
+// normalize interprets vals the same way as values inserted into s.
+func normalize(s Series, vals ...interface{}) []interface{} {
+	ns, ok := s.(NewSerieser)
+	if !ok {
+		return vals
+	}
+
+	tmp := ns.NewSeries("", &SeriesInit{Capacity: len(vals)})
+	out := make([]interface{}, 0, len(vals))
+	for i, v := range vals {
+		tmp.Append(v, dontLock)
+		out = append(out, tmp.Value(i, dontLock))
+	}
+	return out
+}
+
+func (f SchemaField) validate(ctx context.Context, s Series, ec *ErrorCollection) error {
+
+	if !isType(s, f.Type) {
+		ec.AddError(&SeriesError{Series: f.Name, Err: ErrWrongType}, false)
+		return nil
+	}
+
+	var min, max interface{}
+	if f.Min != nil {
+		min = normalize(s, f.Min)[0]
+	}
+	if f.Max != nil {
+		max = normalize(s, f.Max)[0]
+	}
+	enum := normalize(s, f.Enum...)
+
+	rowErr := func(row int, err error) {
+		ec.AddError(&RowError{Row: row, Err: &SeriesError{Series: f.Name, Err: err}}, false)
+	}
+
+	seen := map[string]struct{}{}
+
+	nRows := s.NRows(dontLock)
+	for row := 0; row < nRows; row++ {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		val := s.Value(row, dontLock)
+		if val == nil {
+			if !f.Nullable {
+				rowErr(row, ErrNilValue)
+			}
+			continue
+		}
+
+		str := s.ValueString(row, dontLock)
+
+		if f.Unique {
+			if _, exists := seen[str]; exists {
+				rowErr(row, ErrDuplicateValue)
+			}
+			seen[str] = struct{}{}
+		}
+
+		if (min != nil && s.IsLessThanFunc(val, min) && !s.IsEqualFunc(val, min)) ||
+			(max != nil && s.IsLessThanFunc(max, val) && !s.IsEqualFunc(val, max)) {
+			rowErr(row, ErrOutOfRange)
+		}
+
+		if len(enum) > 0 {
+			var found bool
+			for _, e := range enum {
+				if s.IsEqualFunc(val, e) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				rowErr(row, ErrNotInEnum)
+			}
+		}
+
+		if f.Regex != nil && !f.Regex.MatchString(str) {
+			rowErr(row, ErrNoMatch)
+		}
+
+		if f.Check != nil {
+			if err := f.Check(val); err != nil {
+				rowErr(row, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ package dataframe
 
 import (
 	"context"
+	"time"
 
 	"golang.org/x/exp/rand"
 )
@@ -23,6 +24,33 @@ type IsEqualOptions struct {
 
 	// Check if name is the same.
 	CheckName bool
+
+	// AbsTol is the absolute tolerance used when comparing float64 and complex128 values.
+	// Two values are equal if |a - b| <= AbsTol.
+	AbsTol float64
+
+	// RelTol is the relative tolerance used when comparing float64 and complex128 values.
+	// Two values are equal if |a - b| <= RelTol * max(|a|, |b|).
+	RelTol float64
+
+	// NaNEqual can be set to true if NaN values should be considered equal.
+	//
+	// NOTE: This only applies to Series that can contain NaN values (such as SeriesGeneric).
+	// NaN values in a SeriesFloat64 represent nil values, which are always equal.
+	NaNEqual bool
+
+	// TimeTolerance is the maximum difference between two time.Time values for them to be considered equal.
+	TimeTolerance time.Duration
+
+	// Unordered can be set to true if the order of the values (or rows for a DataFrame) should be ignored.
+	//
+	// NOTE: The comparison takes O(n^2) time.
+	Unordered bool
+}
+
+// Approximate returns true if any option that relaxes equality is set.
+func (o IsEqualOptions) Approximate() bool {
+	return o.AbsTol != 0 || o.RelTol != 0 || o.NaNEqual || o.TimeTolerance != 0 || o.Unordered
 }
 
 // NilCountOptions sets various options for the NilCount function.
@@ -152,6 +180,25 @@ type NewSerieser interface {
 	NewSeries(name string, init *SeriesInit) Series
 }
 
+// Viewer is an interface for a Series to provide a zero-copy view of a subset of its rows.
+type Viewer interface {
+
+	// View returns a Series that shares the underlying storage of the Series for the rows in r.
+	// The storage is copied (copy-on-write) when either Series is subsequently modified.
+	View(r Range) Series
+}
+
+// RLocker is an interface for a Series that can be locked for reading.
+// Read-only operations should prefer it over Lock so that concurrent readers are not blocked.
+type RLocker interface {
+
+	// RLock will lock the Series for reading.
+	RLock()
+
+	// RUnlock will unlock the Series that was previously locked for reading.
+	RUnlock()
+}
+
 // Rander is an interface for generating random float64.
 //
 // See: https://godoc.org/golang.org/x/exp/rand for a random generator source.
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"golang.org/x/exp/rand"
+	"math"
 	"sort"
 	"strconv"
 	"sync"
@@ -28,6 +29,7 @@ type SeriesFloat64 struct {
 	// WARNING: Do not modify directly.
 	Values   []float64
 	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
 }
 
 // NewSeriesFloat64 creates a new series with the underlying type as float64.
@@ -180,6 +182,8 @@ func (s *SeriesFloat64) Prepend(val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
 
 	if cap(s.Values) > len(s.Values) {
@@ -224,6 +228,8 @@ func (s *SeriesFloat64) Insert(row int, val interface{}, opts ...Options) {
 }
 
 func (s *SeriesFloat64) insert(row int, val interface{}) {
+	s.own()
+
 	switch V := val.(type) {
 	case []float64:
 		// count how many NaN
@@ -254,6 +260,8 @@ func (s *SeriesFloat64) Remove(row int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if isNaN(s.Values[row]) {
 		s.nilCount--
 	}
@@ -269,6 +277,7 @@ func (s *SeriesFloat64) Reset(opts ...Options) {
 	}
 
 	s.Values = []float64{}
+	s.shared = false
 	s.nilCount = 0
 }
 
@@ -281,6 +290,8 @@ func (s *SeriesFloat64) Update(row int, val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	newVal := s.valToPointer(val)
 
 	if isNaN(s.Values[row]) && !isNaN(newVal) {
@@ -427,6 +438,8 @@ func (s *SeriesFloat64) Swap(row1, row2 int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	s.Values[row1], s.Values[row2] = s.Values[row2], s.Values[row1]
 }
 
@@ -491,6 +504,8 @@ func (s *SeriesFloat64) Sort(ctx context.Context, opts ...SortOptions) (complete
 		defer s.Unlock()
 	}
 
+	s.own()
+
 	sortFunc := func(i, j int) (ret bool) {
 		if err := ctx.Err(); err != nil {
 			panic(err)
@@ -502,6 +517,14 @@ func (s *SeriesFloat64) Sort(ctx context.Context, opts ...SortOptions) (complete
 			}
 		}()
 
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := isNaN(s.Values[i]), isNaN(s.Values[j])
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
 		if isNaN(s.Values[i]) {
 			if isNaN(s.Values[j]) {
 				// both are nil
@@ -541,6 +564,16 @@ func (s *SeriesFloat64) Unlock() {
 	s.lock.Unlock()
 }
 
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesFloat64) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesFloat64) RUnlock() {
+	s.lock.RUnlock()
+}
+
 // Copy will create a new copy of the series.
 // It is recommended that you lock the Series before attempting
 // to Copy.
@@ -576,6 +609,60 @@ func (s *SeriesFloat64) Copy(r ...Range) Series {
 	}
 }
 
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+//
+// NOTE: Modifying Values directly does not trigger a copy.
+func (s *SeriesFloat64) View(r Range) Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.Values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.Values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.Values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.Values) {
+		nilCount = 0
+		for _, v := range vals {
+			if isNaN(v) {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesFloat64{
+		valFormatter: s.valFormatter,
+		name:         s.name,
+		Values:       vals,
+		nilCount:     nilCount,
+		shared:       true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesFloat64) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([]float64, len(s.Values), cap(s.Values))
+	copy(vals, s.Values)
+	s.Values = vals
+	s.shared = false
+}
+
 // Table will produce the Series in a table.
 func (s *SeriesFloat64) Table(opts ...TableOptions) string {
 
@@ -850,6 +937,8 @@ func (s *SeriesFloat64) FillRand(src rand.Source, probNil float64, rander Rander
 
 	rng := rand.New(src)
 
+	s.own()
+
 	capacity := cap(s.Values)
 	length := len(s.Values)
 	s.nilCount = 0
@@ -903,6 +992,11 @@ func (s *SeriesFloat64) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualO
 		}
 	}
 
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
 	// Check values
 	for i, v := range s.Values {
 		if err := ctx.Err(); err != nil {
@@ -980,3 +1074,119 @@ func (s *SeriesFloat64) AsciiGraph(opts ...AsciiGraphOptions) string {
 
 	return asciigraph.Plot(s.Values[st:en], popts...) + "\n"
 }
+
+type seriesFloat64Binary struct {
+	Name      string
+	Values    []float64
+	Formatter string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+func (s *SeriesFloat64) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return gobEncode(seriesFloat64Binary{
+		Name:      s.name,
+		Values:    s.Values,
+		Formatter: ValueFormatterName(s.valFormatter),
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesFloat64) UnmarshalBinary(data []byte) error {
+	var in seriesFloat64Binary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	if in.Values == nil {
+		in.Values = []float64{}
+	}
+
+	var nilCount int
+	for _, v := range in.Values {
+		if isNaN(v) {
+			nilCount++
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.Values = in.Values
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(in.Formatter)
+	s.shared = false
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Nil values are encoded as null.
+// Infinite values are encoded as the strings "+Inf" and "-Inf".
+func (s *SeriesFloat64) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	vals := make([]interface{}, 0, len(s.Values))
+	for _, v := range s.Values {
+		if isNaN(v) {
+			vals = append(vals, nil)
+		} else if math.IsInf(v, 0) {
+			vals = append(vals, strconv.FormatFloat(v, 'f', -1, 64))
+		} else {
+			vals = append(vals, v)
+		}
+	}
+
+	return marshalSeriesJSON(seriesJSON{
+		Name:      s.name,
+		Type:      "float64",
+		Formatter: ValueFormatterName(s.valFormatter),
+	}, vals)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesFloat64) UnmarshalJSON(data []byte) error {
+	vals := []interface{}{}
+	sj, err := unmarshalSeriesJSON(data, "float64", &vals)
+	if err != nil {
+		return err
+	}
+
+	var nilCount int
+	fs := make([]float64, 0, len(vals))
+	for _, v := range vals {
+		switch v := v.(type) {
+		case nil:
+			fs = append(fs, nan())
+			nilCount++
+		case float64:
+			fs = append(fs, v)
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return err
+			}
+			if isNaN(f) {
+				nilCount++
+			}
+			fs = append(fs, f)
+		default:
+			return fmt.Errorf("invalid value: %v", v)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = sj.Name
+	s.Values = fs
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(sj.Formatter)
+	s.shared = false
+
+	return nil
+}
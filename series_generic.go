@@ -5,8 +5,10 @@ package dataframe
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"sync"
 
@@ -26,6 +28,7 @@ type SeriesGeneric struct {
 	name     string
 	values   []interface{}
 	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
 }
 
 // NewSeriesGeneric creates a new generic series.
@@ -153,6 +156,8 @@ func (s *SeriesGeneric) Prepend(val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
 
 	if cap(s.values) > len(s.values) {
@@ -204,6 +209,8 @@ func (s *SeriesGeneric) Insert(row int, val interface{}, opts ...Options) {
 }
 
 func (s *SeriesGeneric) insert(row int, val interface{}) {
+	s.own()
+
 	s.values = append(s.values, nil)
 	copy(s.values[row+1:], s.values[row:])
 
@@ -225,6 +232,8 @@ func (s *SeriesGeneric) Remove(row int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if s.values[row] == nil {
 		s.nilCount--
 	}
@@ -240,6 +249,7 @@ func (s *SeriesGeneric) Reset(opts ...Options) {
 	}
 
 	s.values = []interface{}{}
+	s.shared = false
 	s.nilCount = 0
 }
 
@@ -252,6 +262,8 @@ func (s *SeriesGeneric) Update(row int, val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if s.values[row] == nil && val != nil {
 		s.nilCount--
 	} else if s.values[row] != nil && val == nil {
@@ -395,6 +407,8 @@ func (s *SeriesGeneric) Sort(ctx context.Context, opts ...SortOptions) (complete
 		defer s.Unlock()
 	}
 
+	s.own()
+
 	sortFunc := func(i, j int) (ret bool) {
 		if err := ctx.Err(); err != nil {
 			panic(err)
@@ -406,6 +420,14 @@ func (s *SeriesGeneric) Sort(ctx context.Context, opts ...SortOptions) (complete
 			}
 		}()
 
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := s.values[i] == nil, s.values[j] == nil
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
 		left := s.values[i]
 		right := s.values[j]
 
@@ -445,6 +467,8 @@ func (s *SeriesGeneric) Swap(row1, row2 int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	s.values[row1], s.values[row2] = s.values[row2], s.values[row1]
 }
 
@@ -459,6 +483,16 @@ func (s *SeriesGeneric) Unlock() {
 	s.lock.Unlock()
 }
 
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesGeneric) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesGeneric) RUnlock() {
+	s.lock.RUnlock()
+}
+
 // Copy will create a new copy of the series.
 // It is recommended that you lock the Series before attempting
 // to Copy.
@@ -504,6 +538,61 @@ func (s *SeriesGeneric) Copy(r ...Range) Series {
 	}
 }
 
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+func (s *SeriesGeneric) View(r Range) Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.values) {
+		nilCount = 0
+		for _, v := range vals {
+			if v == nil {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesGeneric{
+		valFormatter:   s.valFormatter,
+		isEqualFunc:    s.isEqualFunc,
+		isLessThanFunc: s.isLessThanFunc,
+		concreteType:   s.concreteType,
+		name:           s.name,
+		values:         vals,
+		nilCount:       nilCount,
+		shared:         true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesGeneric) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([]interface{}, len(s.values), cap(s.values))
+	copy(vals, s.values)
+	s.values = vals
+	s.shared = false
+}
+
 // Table will produce the Series in a table.
 func (s *SeriesGeneric) Table(opts ...TableOptions) string {
 
@@ -716,6 +805,11 @@ func (s *SeriesGeneric) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualO
 		}
 	}
 
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
 	// Check values
 	for i, v := range s.values {
 		if err := ctx.Err(); err != nil {
@@ -738,3 +832,123 @@ func (s *SeriesGeneric) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualO
 
 	return true, nil
 }
+
+type seriesGenericBinary struct {
+	Name         string
+	ConcreteType interface{}
+	Values       []interface{}
+	Formatter    string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+//
+// NOTE: The concrete type must be registered using gob.Register (except for basic types).
+// The IsEqualFunc and IsLessThanFunc are not preserved.
+func (s *SeriesGeneric) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return gobEncode(seriesGenericBinary{
+		Name:         s.name,
+		ConcreteType: s.concreteType,
+		Values:       s.values,
+		Formatter:    ValueFormatterName(s.valFormatter),
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The IsEqualFunc and IsLessThanFunc of s are retained since functions can't be encoded.
+func (s *SeriesGeneric) UnmarshalBinary(data []byte) error {
+	var in seriesGenericBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	if err := checkConcreteType(in.ConcreteType); err != nil {
+		return err
+	}
+
+	if in.Values == nil {
+		in.Values = []interface{}{}
+	}
+
+	var nilCount int
+	for _, v := range in.Values {
+		if v == nil {
+			nilCount++
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.concreteType = in.ConcreteType
+	s.values = in.Values
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(in.Formatter)
+	if s.isEqualFunc == nil {
+		s.isEqualFunc = DefaultIsEqualFunc
+	}
+	s.shared = false
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Nil values are encoded as null.
+func (s *SeriesGeneric) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return marshalSeriesJSON(seriesJSON{
+		Name:      s.name,
+		Type:      s.Type(),
+		Formatter: ValueFormatterName(s.valFormatter),
+	}, s.values)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// NOTE: The concrete type can't be determined from the JSON, so the Series must be created using
+// NewSeriesGeneric prior to decoding. The values are decoded into the concrete type.
+func (s *SeriesGeneric) UnmarshalJSON(data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.concreteType == nil {
+		return errors.New("concrete type is unknown")
+	}
+
+	raw := []json.RawMessage{}
+	sj, err := unmarshalSeriesJSON(data, s.Type(), &raw)
+	if err != nil {
+		return err
+	}
+
+	t := reflect.TypeOf(s.concreteType)
+
+	var nilCount int
+	vals := make([]interface{}, 0, len(raw))
+	for _, r := range raw {
+		if string(r) == "null" {
+			vals = append(vals, nil)
+			nilCount++
+			continue
+		}
+
+		v := reflect.New(t)
+		if err := json.Unmarshal(r, v.Interface()); err != nil {
+			return err
+		}
+		vals = append(vals, v.Elem().Interface())
+	}
+
+	s.name = sj.Name
+	s.values = vals
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(sj.Formatter)
+	s.shared = false
+
+	return nil
+}
@@ -0,0 +1,1113 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// SeriesList is a series of data where each value is a list of values of a child type.
+// The child type is determined by a Series (eg. SeriesFloat64). A list can contain nil values.
+//
+// The value of each row is a []interface{} containing values of the child type (or nil).
+// When a list is set, any slice can be provided. Each element is converted by the child Series.
+type SeriesList struct {
+	valFormatter   ValueToStringFormatter
+	isEqualFunc    IsEqualFunc
+	isLessThanFunc IsLessThanFunc
+
+	child Series // An empty Series that determines the type of the elements
+
+	lock     sync.RWMutex
+	name     string
+	values   [][]interface{}
+	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
+}
+
+// NewSeriesList creates a new series where each value is a list of values of the same type as child.
+// child must implement the NewSerieser interface. Its values are ignored.
+//
+// Example:
+//
+//  s := dataframe.NewSeriesList("tags", dataframe.NewSeriesString("", nil), nil, []string{"a", "b"}, nil, []interface{}{"c", nil})
+//
+func NewSeriesList(name string, child Series, init *SeriesInit, vals ...interface{}) *SeriesList {
+
+	ns, ok := child.(NewSerieser)
+	if !ok {
+		panic(fmt.Errorf("%T does not implement NewSerieser interface", child))
+	}
+
+	s := &SeriesList{
+		valFormatter: DefaultValueFormatter,
+		isEqualFunc:  DefaultIsEqualFunc,
+		name:         name,
+		child:        ns.NewSeries("", nil),
+		nilCount:     0,
+	}
+	s.isLessThanFunc = s.listLess
+
+	var (
+		size     int
+		capacity int
+	)
+
+	if init != nil {
+		size = init.Size
+		capacity = init.Capacity
+		if size > capacity {
+			capacity = size
+		}
+	}
+
+	s.values = make([][]interface{}, size, capacity)
+
+	for idx, v := range vals {
+		l, err := s.list(v)
+		if err != nil {
+			panic(err)
+		}
+		if l == nil {
+			s.nilCount++
+		}
+
+		if idx < size {
+			s.values[idx] = l
+		} else {
+			s.values = append(s.values, l)
+		}
+	}
+
+	if len(vals) < size {
+		s.nilCount = s.nilCount + size - len(vals)
+	}
+
+	return s
+}
+
+// NewSeries creates a new initialized SeriesList with the same child type.
+func (s *SeriesList) NewSeries(name string, init *SeriesInit) Series {
+	return NewSeriesList(name, s.child, init)
+}
+
+// NewChildSeries creates a new initialized Series of the child type.
+func (s *SeriesList) NewChildSeries(name string, init *SeriesInit) Series {
+	return s.child.(NewSerieser).NewSeries(name, init)
+}
+
+// list converts val into a list. Each element is converted by the child Series.
+func (s *SeriesList) list(val interface{}) (_ []interface{}, rErr error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%T is not a slice", val)
+	}
+
+	if rv.IsNil() {
+		return nil, nil
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			rErr = fmt.Errorf("invalid element for %s: %v", s.child.Type(), x)
+		}
+	}()
+
+	cs := s.NewChildSeries("", &SeriesInit{Capacity: rv.Len()})
+
+	out := make([]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		cs.Append(rv.Index(i).Interface(), dontLock)
+		out = append(out, cs.Value(i, dontLock))
+	}
+	return out, nil
+}
+
+// listLess compares 2 lists lexicographically using the child Series. Nil elements are less than
+// other elements.
+func (s *SeriesList) listLess(a, b interface{}) bool {
+	x, y := a.([]interface{}), b.([]interface{})
+
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] == nil || y[i] == nil {
+			if x[i] == nil && y[i] == nil {
+				continue
+			}
+			return x[i] == nil
+		}
+
+		if s.child.IsEqualFunc(x[i], y[i]) {
+			continue
+		}
+		return s.child.IsLessThanFunc(x[i], y[i])
+	}
+	return len(x) < len(y)
+}
+
+// Name returns the series name.
+func (s *SeriesList) Name(opts ...Options) string {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.name
+}
+
+// Rename renames the series.
+func (s *SeriesList) Rename(n string, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.name = n
+}
+
+// Type returns the type of data the series holds.
+func (s *SeriesList) Type() string {
+	return fmt.Sprintf("list(%s)", s.child.Type())
+}
+
+// NRows returns how many rows the series contains.
+func (s *SeriesList) NRows(opts ...Options) int {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return len(s.values)
+}
+
+// Value returns the value of a particular row.
+// The return value could be nil or a []interface{}.
+// A copy of the list is returned.
+func (s *SeriesList) Value(row int, opts ...Options) interface{} {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	val := s.values[row]
+	if val == nil {
+		return nil
+	}
+	return append([]interface{}{}, val...)
+}
+
+// ValueString returns a string representation of a
+// particular row. The string representation is defined
+// by the function set in SetValueToStringFormatter.
+// By default, a nil value is returned as "NaN".
+func (s *SeriesList) ValueString(row int, opts ...Options) string {
+	return s.valFormatter(s.Value(row, opts...))
+}
+
+// Prepend is used to set a value to the beginning of the
+// series. val can be a slice or nil. Nil
+// represents the absence of a value.
+func (s *SeriesList) Prepend(val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.insert(0, val)
+}
+
+// Append is used to set a value to the end of the series.
+// val can be a slice or nil. Nil represents
+// the absence of a value.
+func (s *SeriesList) Append(val interface{}, opts ...Options) int {
+	var locked bool
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		locked = true
+	}
+
+	row := s.NRows(Options{DontLock: locked})
+	s.insert(row, val)
+	return row
+}
+
+// Insert is used to set a value at an arbitrary row in
+// the series. All existing values from that row onwards
+// are shifted by 1. val can be a slice or nil.
+// Nil represents the absence of a value.
+func (s *SeriesList) Insert(row int, val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.insert(row, val)
+}
+
+func (s *SeriesList) insert(row int, val interface{}) {
+	l, err := s.list(val)
+	if err != nil {
+		panic(err)
+	}
+
+	s.own()
+
+	s.values = append(s.values, nil)
+	copy(s.values[row+1:], s.values[row:])
+	s.values[row] = l
+
+	if l == nil {
+		s.nilCount++
+	}
+}
+
+// Remove is used to delete the value of a particular row.
+func (s *SeriesList) Remove(row int, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.own()
+
+	if s.values[row] == nil {
+		s.nilCount--
+	}
+
+	s.values = append(s.values[:row], s.values[row+1:]...)
+}
+
+// Reset is used clear all data contained in the Series.
+func (s *SeriesList) Reset(opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.values = [][]interface{}{}
+	s.shared = false
+	s.nilCount = 0
+}
+
+// Update is used to update the value of a particular row.
+// val can be a slice or nil. Nil represents
+// the absence of a value.
+func (s *SeriesList) Update(row int, val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	l, err := s.list(val)
+	if err != nil {
+		panic(err)
+	}
+
+	s.own()
+
+	if s.values[row] == nil && l != nil {
+		s.nilCount--
+	} else if s.values[row] != nil && l == nil {
+		s.nilCount++
+	}
+
+	s.values[row] = l
+}
+
+// ValuesIterator will return a function that can be used to iterate through all the values.
+func (s *SeriesList) ValuesIterator(opts ...ValuesOptions) func() (*int, interface{}, int) {
+
+	var (
+		row  int
+		step int = 1
+	)
+
+	var dontReadLock bool
+
+	if len(opts) > 0 {
+		dontReadLock = opts[0].DontReadLock
+
+		row = opts[0].InitialRow
+		if row < 0 {
+			row = len(s.values) + row
+		}
+		if opts[0].Step != 0 {
+			step = opts[0].Step
+		}
+	}
+
+	initial := row
+
+	return func() (*int, interface{}, int) {
+		if !dontReadLock {
+			s.lock.RLock()
+			defer s.lock.RUnlock()
+		}
+
+		var t int
+		if step > 0 {
+			t = (len(s.values)-initial-1)/step + 1
+		} else {
+			t = -initial/step + 1
+		}
+
+		if row > len(s.values)-1 || row < 0 {
+			// Don't iterate further
+			return nil, nil, t
+		}
+
+		out := s.Value(row, dontLock)
+		row = row + step
+		return &[]int{row - step}[0], out, t
+	}
+}
+
+// SetValueToStringFormatter is used to set a function
+// to convert the value of a particular row to a string
+// representation.
+func (s *SeriesList) SetValueToStringFormatter(f ValueToStringFormatter) {
+	if f == nil {
+		s.valFormatter = DefaultValueFormatter
+		return
+	}
+	s.valFormatter = f
+}
+
+// IsEqualFunc returns true if a is equal to b.
+func (s *SeriesList) IsEqualFunc(a, b interface{}) bool {
+
+	if s.isEqualFunc == nil {
+		panic(errors.New("IsEqualFunc not set"))
+	}
+
+	return s.isEqualFunc(a, b)
+}
+
+// IsLessThanFunc returns true if a is less than b.
+// By default, lists are compared lexicographically using the child Series.
+func (s *SeriesList) IsLessThanFunc(a, b interface{}) bool {
+
+	if s.isLessThanFunc == nil {
+		panic(errors.New("IsLessThanFunc not set"))
+	}
+
+	return s.isLessThanFunc(a, b)
+}
+
+// SetIsEqualFunc sets a function which can be used to determine
+// if 2 values in the series are equal.
+func (s *SeriesList) SetIsEqualFunc(f IsEqualFunc) {
+	if f == nil {
+		// Return to default
+		s.isEqualFunc = DefaultIsEqualFunc
+	} else {
+		s.isEqualFunc = f
+	}
+}
+
+// SetIsLessThanFunc sets a function which can be used to determine
+// if a value is less than another in the series.
+func (s *SeriesList) SetIsLessThanFunc(f IsLessThanFunc) {
+	if f == nil {
+		// Return to default
+		s.isLessThanFunc = s.listLess
+	} else {
+		s.isLessThanFunc = f
+	}
+}
+
+// Sort will sort the series.
+// It will return true if sorting was completed or false when the context is canceled.
+func (s *SeriesList) Sort(ctx context.Context, opts ...SortOptions) (completed bool) {
+
+	defer func() {
+		if x := recover(); x != nil {
+			completed = false
+		}
+	}()
+
+	if len(opts) == 0 {
+		opts = append(opts, SortOptions{})
+	}
+
+	if !opts[0].DontLock {
+		s.Lock()
+		defer s.Unlock()
+	}
+
+	s.own()
+
+	sortFunc := func(i, j int) (ret bool) {
+		if err := ctx.Err(); err != nil {
+			panic(err)
+		}
+
+		defer func() {
+			if opts[0].Desc {
+				ret = !ret
+			}
+		}()
+
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := s.values[i] == nil, s.values[j] == nil
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
+		left := s.values[i]
+		right := s.values[j]
+
+		if left == nil {
+			// left is nil (regardless of right)
+			return true
+		}
+
+		if right == nil {
+			// left has value and right is nil
+			return false
+		}
+		// Both are not nil
+		return s.isLessThanFunc(left, right)
+	}
+
+	if opts[0].Stable {
+		sort.SliceStable(s.values, sortFunc)
+	} else {
+		sort.Slice(s.values, sortFunc)
+	}
+
+	return true
+}
+
+// Swap is used to swap 2 values based on their row position.
+func (s *SeriesList) Swap(row1, row2 int, opts ...Options) {
+	if row1 == row2 {
+		return
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.own()
+
+	s.values[row1], s.values[row2] = s.values[row2], s.values[row1]
+}
+
+// Lock will lock the Series allowing you to directly manipulate
+// the underlying slice with confidence.
+func (s *SeriesList) Lock() {
+	s.lock.Lock()
+}
+
+// Unlock will unlock the Series that was previously locked.
+func (s *SeriesList) Unlock() {
+	s.lock.Unlock()
+}
+
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesList) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesList) RUnlock() {
+	s.lock.RUnlock()
+}
+
+// Copy will create a new copy of the series.
+// It is recommended that you lock the Series before attempting
+// to Copy.
+func (s *SeriesList) Copy(r ...Range) Series {
+
+	out := &SeriesList{
+		valFormatter:   s.valFormatter,
+		isEqualFunc:    s.isEqualFunc,
+		isLessThanFunc: s.isLessThanFunc,
+
+		child: s.child,
+
+		name:     s.name,
+		values:   [][]interface{}{},
+		nilCount: s.nilCount,
+	}
+
+	if len(s.values) == 0 {
+		return out
+	}
+
+	if len(r) == 0 {
+		r = append(r, Range{})
+	}
+
+	start, end, err := r[0].Limits(len(s.values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Copy slice (the lists are never modified in place, so they can be shared)
+	x := s.values[start : end+1]
+	out.values = append(x[:0:0], x...)
+
+	if len(out.values) != len(s.values) {
+		out.nilCount = 0
+		for _, v := range out.values {
+			if v == nil {
+				out.nilCount++
+			}
+		}
+	}
+
+	return out
+}
+
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+func (s *SeriesList) View(r Range) Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.values) {
+		nilCount = 0
+		for _, v := range vals {
+			if v == nil {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesList{
+		valFormatter:   s.valFormatter,
+		isEqualFunc:    s.isEqualFunc,
+		isLessThanFunc: s.isLessThanFunc,
+		child:          s.child,
+		name:           s.name,
+		values:         vals,
+		nilCount:       nilCount,
+		shared:         true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesList) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([][]interface{}, len(s.values), cap(s.values))
+	copy(vals, s.values)
+	s.values = vals
+	s.shared = false
+}
+
+// Table will produce the Series in a table.
+func (s *SeriesList) Table(opts ...TableOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, TableOptions{R: &Range{}})
+	}
+
+	if !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	data := [][]string{}
+
+	headers := []string{"", s.name} // row header is blank
+	footers := []string{fmt.Sprintf("%dx%d", len(s.values), 1), s.Type()}
+
+	if len(s.values) > 0 {
+
+		start, end, err := opts[0].R.Limits(len(s.values))
+		if err != nil {
+			panic(err)
+		}
+
+		for row := start; row <= end; row++ {
+			sVals := []string{fmt.Sprintf("%d:", row), s.ValueString(row, dontLock)}
+			data = append(data, sVals)
+		}
+
+	}
+
+	var buf bytes.Buffer
+
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(headers)
+	for _, v := range data {
+		table.Append(v)
+	}
+	table.SetFooter(footers)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+
+	table.Render()
+
+	return buf.String()
+}
+
+// String implements the fmt.Stringer interface. It does not lock the Series.
+func (s *SeriesList) String() string {
+
+	count := len(s.values)
+
+	out := s.name + ": [ "
+
+	if count > 6 {
+		idx := []int{0, 1, 2, count - 3, count - 2, count - 1}
+		for j, row := range idx {
+			if j == 3 {
+				out = out + "... "
+			}
+			out = out + s.ValueString(row, dontLock) + " "
+		}
+		return out + "]"
+	}
+
+	for row := range s.values {
+		out = out + s.ValueString(row, dontLock) + " "
+	}
+	return out + "]"
+}
+
+// ContainsNil will return whether or not the series contains any nil values.
+func (s *SeriesList) ContainsNil(opts ...Options) bool {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.nilCount > 0
+}
+
+// NilCount will return how many nil values are in the series.
+// An empty list is not a nil value.
+func (s *SeriesList) NilCount(opts ...NilCountOptions) (int, error) {
+	if len(opts) == 0 {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		return s.nilCount, nil
+	}
+
+	if !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	var (
+		ctx context.Context
+		r   *Range
+	)
+
+	if opts[0].Ctx == nil {
+		ctx = context.Background()
+	} else {
+		ctx = opts[0].Ctx
+	}
+
+	if opts[0].R == nil {
+		r = &Range{}
+	} else {
+		r = opts[0].R
+	}
+
+	start, end, err := r.Limits(len(s.values))
+	if err != nil {
+		return 0, err
+	}
+
+	if start == 0 && end == len(s.values)-1 {
+		return s.nilCount, nil
+	}
+
+	var nilCount int
+
+	for i := start; i <= end; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if s.values[i] == nil {
+
+			if opts[0].StopAtOneNil {
+				return 1, nil
+			}
+
+			nilCount++
+		}
+	}
+
+	return nilCount, nil
+}
+
+// IsEqual returns true if s2's values are equal to s.
+func (s *SeriesList) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOptions) (bool, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	// Check type
+	ls, ok := s2.(*SeriesList)
+	if !ok || s.Type() != ls.Type() {
+		return false, nil
+	}
+
+	// Check number of values
+	if len(s.values) != len(ls.values) {
+		return false, nil
+	}
+
+	// Check name
+	if len(opts) != 0 && opts[0].CheckName {
+		if s.name != ls.name {
+			return false, nil
+		}
+	}
+
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
+	// Check values
+	for i, v := range s.values {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		if v == nil {
+			if ls.values[i] == nil {
+				// Both are nil
+				continue
+			} else {
+				return false, nil
+			}
+		}
+
+		if ls.values[i] == nil || !s.isEqualFunc(v, ls.values[i]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// flatten returns a Series of the child type containing the elements of every list and
+// the length of each list (-1 for a nil value).
+func (s *SeriesList) flatten() (Series, []int) {
+	var n int
+	for _, v := range s.values {
+		n = n + len(v)
+	}
+
+	cs := s.NewChildSeries("", &SeriesInit{Capacity: n})
+	lengths := make([]int, 0, len(s.values))
+
+	for _, v := range s.values {
+		if v == nil {
+			lengths = append(lengths, -1)
+			continue
+		}
+		for _, e := range v {
+			cs.Append(e, dontLock)
+		}
+		lengths = append(lengths, len(v))
+	}
+	return cs, lengths
+}
+
+// unflatten sets the values using the elements of cs and the length of each list (-1 for a nil value).
+func (s *SeriesList) unflatten(cs Series, lengths []int) error {
+	var n int
+	for _, l := range lengths {
+		if l > 0 {
+			n = n + l
+		}
+	}
+
+	if cs.NRows(dontLock) != n {
+		return fmt.Errorf("expected %d elements but got %d", n, cs.NRows(dontLock))
+	}
+
+	var (
+		idx      int
+		nilCount int
+	)
+
+	vals := make([][]interface{}, 0, len(lengths))
+	for _, l := range lengths {
+		if l < 0 {
+			vals = append(vals, nil)
+			nilCount++
+			continue
+		}
+
+		list := make([]interface{}, 0, l)
+		for i := 0; i < l; i++ {
+			list = append(list, cs.Value(idx, dontLock))
+			idx++
+		}
+		vals = append(vals, list)
+	}
+
+	s.values = vals
+	s.nilCount = nilCount
+	s.shared = false
+
+	return nil
+}
+
+type seriesListBinary struct {
+	Name      string
+	Child     seriesBinary // The elements of every list
+	Lengths   []int
+	Formatter string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+//
+// NOTE: The child Series must be registered using RegisterSeries.
+// The IsEqualFunc and IsLessThanFunc are not preserved.
+func (s *SeriesList) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	cs, lengths := s.flatten()
+
+	typ, exists := registeredName(cs)
+	if !exists {
+		return nil, fmt.Errorf("%T is not registered", cs)
+	}
+
+	data, err := cs.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return gobEncode(seriesListBinary{
+		Name:      s.name,
+		Child:     seriesBinary{Type: typ, Data: data},
+		Lengths:   lengths,
+		Formatter: ValueFormatterName(s.valFormatter),
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesList) UnmarshalBinary(data []byte) error {
+	var in seriesListBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	cs, exists := newRegistered(in.Child.Type)
+	if !exists {
+		return fmt.Errorf("%s is not registered", in.Child.Type)
+	}
+
+	if _, ok := cs.(NewSerieser); !ok {
+		return fmt.Errorf("%T does not implement NewSerieser interface", cs)
+	}
+
+	if err := cs.(encoding.BinaryUnmarshaler).UnmarshalBinary(in.Child.Data); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.child = cs.(NewSerieser).NewSeries("", nil)
+	if err := s.unflatten(cs, in.Lengths); err != nil {
+		return err
+	}
+
+	s.name = in.Name
+	s.valFormatter = LookupValueFormatter(in.Formatter)
+	s.isEqualFunc = DefaultIsEqualFunc
+	s.isLessThanFunc = s.listLess
+
+	return nil
+}
+
+// childJSON is the JSON representation of the child Series of a SeriesList or a field of a SeriesStruct.
+type childJSON struct {
+	Series string          `json:"series"` // Name used to register the Series
+	Data   json.RawMessage `json:"data"`   // JSON representation of the Series
+}
+
+// MarshalJSON implements the json.Marshaler interface. Each list is encoded as an array using
+// the JSON representation of the child Series. Nil values are encoded as null.
+//
+// NOTE: The child Series must be registered using RegisterSeries and implement json.Marshaler.
+func (s *SeriesList) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	cs, lengths := s.flatten()
+
+	typ, exists := registeredName(cs)
+	if !exists {
+		return nil, fmt.Errorf("%T is not registered", cs)
+	}
+
+	m, ok := cs.(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement json.Marshaler", cs)
+	}
+
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// Split the values of the child Series into lists
+	var csj seriesJSON
+	if err := json.Unmarshal(data, &csj); err != nil {
+		return nil, err
+	}
+
+	elems := []json.RawMessage{}
+	if err := json.Unmarshal(csj.Values, &elems); err != nil {
+		return nil, err
+	}
+
+	var idx int
+	vals := make([][]json.RawMessage, 0, len(lengths))
+	for _, l := range lengths {
+		if l < 0 {
+			vals = append(vals, nil)
+			continue
+		}
+		vals = append(vals, elems[idx:idx+l:idx+l])
+		idx = idx + l
+	}
+
+	csj.Values = nil
+	child, err := json.Marshal(csj)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalSeriesJSON(seriesJSON{
+		Name:      s.name,
+		Type:      s.Type(),
+		Formatter: ValueFormatterName(s.valFormatter),
+		Child:     &childJSON{Series: typ, Data: child},
+	}, vals)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesList) UnmarshalJSON(data []byte) error {
+	var sj seriesJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return err
+	}
+
+	if sj.Child == nil {
+		return errors.New("child series is unknown")
+	}
+
+	cs, exists := newRegistered(sj.Child.Series)
+	if !exists {
+		return fmt.Errorf("%s is not registered", sj.Child.Series)
+	}
+
+	if _, ok := cs.(NewSerieser); !ok {
+		return fmt.Errorf("%T does not implement NewSerieser interface", cs)
+	}
+
+	u, ok := cs.(json.Unmarshaler)
+	if !ok {
+		return fmt.Errorf("%T does not implement json.Unmarshaler", cs)
+	}
+
+	// Decode the elements of every list using the child Series
+	lists := [][]json.RawMessage{}
+	if _, err := unmarshalSeriesJSON(data, fmt.Sprintf("list(%s)", cs.Type()), &lists); err != nil {
+		return err
+	}
+
+	elems := []json.RawMessage{}
+	lengths := make([]int, 0, len(lists))
+	for _, l := range lists {
+		if l == nil {
+			lengths = append(lengths, -1)
+			continue
+		}
+		elems = append(elems, l...)
+		lengths = append(lengths, len(l))
+	}
+
+	var csj seriesJSON
+	if err := json.Unmarshal(sj.Child.Data, &csj); err != nil {
+		return err
+	}
+
+	vals, err := json.Marshal(elems)
+	if err != nil {
+		return err
+	}
+	csj.Values = vals
+
+	cdata, err := json.Marshal(csj)
+	if err != nil {
+		return err
+	}
+
+	if err := u.UnmarshalJSON(cdata); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.child = cs.(NewSerieser).NewSeries("", nil)
+	if err := s.unflatten(cs, lengths); err != nil {
+		return err
+	}
+
+	s.name = sj.Name
+	s.valFormatter = LookupValueFormatter(sj.Formatter)
+	if s.isEqualFunc == nil {
+		s.isEqualFunc = DefaultIsEqualFunc
+	}
+	if s.isLessThanFunc == nil {
+		s.isLessThanFunc = s.listLess
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ type SeriesMixed struct {
 	name     string
 	values   []interface{}
 	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
 }
 
 // NewSeriesMixed creates a new series with the underlying type as interface{}.
@@ -176,6 +177,8 @@ func (s *SeriesMixed) Prepend(val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
 
 	if cap(s.values) > len(s.values) {
@@ -220,6 +223,8 @@ func (s *SeriesMixed) Insert(row int, val interface{}, opts ...Options) {
 }
 
 func (s *SeriesMixed) insert(row int, val interface{}) {
+	s.own()
+
 	switch V := val.(type) {
 	case []interface{}:
 		// count how many NaN
@@ -250,6 +255,8 @@ func (s *SeriesMixed) Remove(row int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if s.values[row] == nil {
 		s.nilCount--
 	}
@@ -265,6 +272,7 @@ func (s *SeriesMixed) Reset(opts ...Options) {
 	}
 
 	s.values = []interface{}{}
+	s.shared = false
 	s.nilCount = 0
 }
 
@@ -277,6 +285,8 @@ func (s *SeriesMixed) Update(row int, val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	newVal := s.valToPointer(val)
 
 	if s.values[row] == nil && newVal != nil {
@@ -460,6 +470,8 @@ func (s *SeriesMixed) Swap(row1, row2 int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	s.values[row1], s.values[row2] = s.values[row2], s.values[row1]
 }
 
@@ -528,6 +540,8 @@ func (s *SeriesMixed) Sort(ctx context.Context, opts ...SortOptions) (completed
 		defer s.Unlock()
 	}
 
+	s.own()
+
 	sortFunc := func(i, j int) (ret bool) {
 		if err := ctx.Err(); err != nil {
 			panic(err)
@@ -539,6 +553,14 @@ func (s *SeriesMixed) Sort(ctx context.Context, opts ...SortOptions) (completed
 			}
 		}()
 
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := s.values[i] == nil, s.values[j] == nil
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
 		left := s.values[i]
 		right := s.values[j]
 
@@ -578,6 +600,16 @@ func (s *SeriesMixed) Unlock() {
 	s.lock.Unlock()
 }
 
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesMixed) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesMixed) RUnlock() {
+	s.lock.RUnlock()
+}
+
 // Copy will create a new copy of the series.
 // It is recommended that you lock the Series before attempting
 // to Copy.
@@ -613,6 +645,60 @@ func (s *SeriesMixed) Copy(r ...Range) Series {
 	}
 }
 
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+func (s *SeriesMixed) View(r Range) Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.values) {
+		nilCount = 0
+		for _, v := range vals {
+			if v == nil {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesMixed{
+		valFormatter:   s.valFormatter,
+		isEqualFunc:    s.isEqualFunc,
+		isLessThanFunc: s.isLessThanFunc,
+		name:           s.name,
+		values:         vals,
+		nilCount:       nilCount,
+		shared:         true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesMixed) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([]interface{}, len(s.values), cap(s.values))
+	copy(vals, s.values)
+	s.values = vals
+	s.shared = false
+}
+
 // Table will produce the Series in a table.
 func (s *SeriesMixed) Table(opts ...TableOptions) string {
 
@@ -810,6 +896,8 @@ func (s *SeriesMixed) FillRand(src rand.Source, probNil float64, rander Rander,
 
 	rng := rand.New(src)
 
+	s.own()
+
 	capacity := cap(s.values)
 	length := len(s.values)
 	s.nilCount = 0
@@ -863,6 +951,11 @@ func (s *SeriesMixed) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOpt
 		}
 	}
 
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
 	// Check values
 	for i, v := range s.values {
 		if err := ctx.Err(); err != nil {
@@ -885,3 +978,102 @@ func (s *SeriesMixed) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOpt
 
 	return true, nil
 }
+
+type seriesMixedBinary struct {
+	Name      string
+	Values    []interface{}
+	Formatter string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+//
+// NOTE: The data types of the values must be registered using gob.Register (except for basic types).
+// The IsEqualFunc and IsLessThanFunc are not preserved.
+func (s *SeriesMixed) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return gobEncode(seriesMixedBinary{
+		Name:      s.name,
+		Values:    s.values,
+		Formatter: ValueFormatterName(s.valFormatter),
+	})
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesMixed) UnmarshalBinary(data []byte) error {
+	var in seriesMixedBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	if in.Values == nil {
+		in.Values = []interface{}{}
+	}
+
+	var nilCount int
+	for _, v := range in.Values {
+		if v == nil {
+			nilCount++
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.values = in.Values
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(in.Formatter)
+	s.isEqualFunc = DefaultIsEqualFunc
+	s.isLessThanFunc = nil
+	s.shared = false
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Nil values are encoded as null.
+//
+// NOTE: The data types of the values are not preserved. When decoded, numbers are stored as float64.
+func (s *SeriesMixed) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return marshalSeriesJSON(seriesJSON{
+		Name:      s.name,
+		Type:      "mixed",
+		Formatter: ValueFormatterName(s.valFormatter),
+	}, s.values)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesMixed) UnmarshalJSON(data []byte) error {
+	vals := []interface{}{}
+	sj, err := unmarshalSeriesJSON(data, "mixed", &vals)
+	if err != nil {
+		return err
+	}
+
+	var nilCount int
+	for _, v := range vals {
+		if v == nil {
+			nilCount++
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.isEqualFunc == nil {
+		s.isEqualFunc = DefaultIsEqualFunc
+	}
+
+	s.name = sj.Name
+	s.values = vals
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(sj.Formatter)
+	s.shared = false
+
+	return nil
+}
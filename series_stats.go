@@ -4,6 +4,8 @@ package dataframe
 
 import (
 	"context"
+	"sort"
+	"time"
 )
 
 // Mean returns the mean. All non-nil values are ignored.
@@ -111,3 +113,69 @@ func (s *SeriesInt64) Sum(ctx context.Context) (float64, error) {
 
 	return float64(sum), nil
 }
+
+// Mean returns the mean. All nil values are ignored.
+// If all values are nil, ErrNoRows is returned.
+func (s *SeriesDuration) Mean(ctx context.Context) (time.Duration, error) {
+
+	sum, err := s.Sum(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := len(s.values) - s.nilCount
+	if count == 0 {
+		return 0, ErrNoRows
+	}
+
+	return sum / time.Duration(count), nil
+}
+
+// Sum returns the sum of all non-nil values. If all values are nil, 0 is returned.
+func (s *SeriesDuration) Sum(ctx context.Context) (time.Duration, error) {
+
+	var sum time.Duration
+
+	for _, v := range s.values {
+
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if v != nil {
+			sum = sum + *v
+		}
+	}
+
+	return sum, nil
+}
+
+// Median returns the median. All nil values are ignored. When there is an even number of
+// non-nil values, the mean of the 2 middle values is returned.
+// If all values are nil, ErrNoRows is returned.
+func (s *SeriesDuration) Median(ctx context.Context) (time.Duration, error) {
+
+	vals := make([]time.Duration, 0, len(s.values)-s.nilCount)
+	for _, v := range s.values {
+
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if v != nil {
+			vals = append(vals, *v)
+		}
+	}
+
+	if len(vals) == 0 {
+		return 0, ErrNoRows
+	}
+
+	sort.Slice(vals, func(i, j int) bool { return vals[i] < vals[j] })
+
+	mid := len(vals) / 2
+	if len(vals)%2 == 1 {
+		return vals[mid], nil
+	}
+	return vals[mid-1] + (vals[mid]-vals[mid-1])/2, nil
+}
@@ -22,6 +22,7 @@ type SeriesString struct {
 	name     string
 	values   []*string
 	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
 }
 
 // NewSeriesString creates a new series with the underlying type as string.
@@ -167,6 +168,8 @@ func (s *SeriesString) Prepend(val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
 
 	if cap(s.values) > len(s.values) {
@@ -211,6 +214,8 @@ func (s *SeriesString) Insert(row int, val interface{}, opts ...Options) {
 }
 
 func (s *SeriesString) insert(row int, val interface{}) {
+	s.own()
+
 	switch V := val.(type) {
 	case []string:
 		var vals []*string
@@ -248,6 +253,8 @@ func (s *SeriesString) Remove(row int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if s.values[row] == nil {
 		s.nilCount--
 	}
@@ -262,6 +269,7 @@ func (s *SeriesString) Reset(opts ...Options) {
 	}
 
 	s.values = []*string{}
+	s.shared = false
 	s.nilCount = 0
 }
 
@@ -274,6 +282,8 @@ func (s *SeriesString) Update(row int, val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	newVal := s.valToPointer(val)
 
 	if s.values[row] == nil && newVal != nil {
@@ -428,6 +438,8 @@ func (s *SeriesString) Swap(row1, row2 int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	s.values[row1], s.values[row2] = s.values[row2], s.values[row1]
 }
 
@@ -490,6 +502,8 @@ func (s *SeriesString) Sort(ctx context.Context, opts ...SortOptions) (completed
 		defer s.Unlock()
 	}
 
+	s.own()
+
 	sortFunc := func(i, j int) (ret bool) {
 		if err := ctx.Err(); err != nil {
 			panic(err)
@@ -501,6 +515,14 @@ func (s *SeriesString) Sort(ctx context.Context, opts ...SortOptions) (completed
 			}
 		}()
 
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := s.values[i] == nil, s.values[j] == nil
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
 		if s.values[i] == nil {
 			if s.values[j] == nil {
 				// both are nil
@@ -540,6 +562,16 @@ func (s *SeriesString) Unlock() {
 	s.lock.Unlock()
 }
 
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesString) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesString) RUnlock() {
+	s.lock.RUnlock()
+}
+
 // Copy will create a new copy of the series.
 // It is recommended that you lock the Series before attempting
 // to Copy.
@@ -575,6 +607,58 @@ func (s *SeriesString) Copy(r ...Range) Series {
 	}
 }
 
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+func (s *SeriesString) View(r Range) Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.values) {
+		nilCount = 0
+		for _, v := range vals {
+			if v == nil {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesString{
+		valFormatter: s.valFormatter,
+		name:         s.name,
+		values:       vals,
+		nilCount:     nilCount,
+		shared:       true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesString) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([]*string, len(s.values), cap(s.values))
+	copy(vals, s.values)
+	s.values = vals
+	s.shared = false
+}
+
 // Table will produce the Series in a table.
 func (s *SeriesString) Table(opts ...TableOptions) string {
 
@@ -892,6 +976,8 @@ func (s *SeriesString) FillRand(src rand.Source, probNil float64, rander Rander,
 
 	rng := rand.New(src)
 
+	s.own()
+
 	capacity := cap(s.values)
 	length := len(s.values)
 	s.nilCount = 0
@@ -945,6 +1031,11 @@ func (s *SeriesString) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOp
 		}
 	}
 
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
 	// Check values
 	for i, v := range s.values {
 		if err := ctx.Err(); err != nil {
@@ -967,3 +1058,102 @@ func (s *SeriesString) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOp
 
 	return true, nil
 }
+
+type seriesStringBinary struct {
+	Name      string
+	Values    []string
+	Nils      []int
+	Formatter string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+func (s *SeriesString) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	out := seriesStringBinary{
+		Name:      s.name,
+		Values:    make([]string, len(s.values)),
+		Formatter: ValueFormatterName(s.valFormatter),
+	}
+
+	for idx, v := range s.values {
+		if v == nil {
+			out.Nils = append(out.Nils, idx)
+		} else {
+			out.Values[idx] = *v
+		}
+	}
+
+	return gobEncode(out)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesString) UnmarshalBinary(data []byte) error {
+	var in seriesStringBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	vals := make([]*string, len(in.Values))
+	for idx := range in.Values {
+		vals[idx] = &in.Values[idx]
+	}
+	for _, idx := range in.Nils {
+		if idx < 0 || idx >= len(vals) {
+			return fmt.Errorf("invalid nil row: %d", idx)
+		}
+		vals[idx] = nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.values = vals
+	s.nilCount = len(in.Nils)
+	s.valFormatter = LookupValueFormatter(in.Formatter)
+	s.shared = false
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Nil values are encoded as null.
+func (s *SeriesString) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return marshalSeriesJSON(seriesJSON{
+		Name:      s.name,
+		Type:      "string",
+		Formatter: ValueFormatterName(s.valFormatter),
+	}, s.values)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesString) UnmarshalJSON(data []byte) error {
+	vals := []*string{}
+	sj, err := unmarshalSeriesJSON(data, "string", &vals)
+	if err != nil {
+		return err
+	}
+
+	var nilCount int
+	for _, v := range vals {
+		if v == nil {
+			nilCount++
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = sj.Name
+	s.values = vals
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(sj.Formatter)
+	s.shared = false
+
+	return nil
+}
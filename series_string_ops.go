@@ -0,0 +1,357 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// PadSide determines which side of a string is padded.
+type PadSide int
+
+const (
+	// PadLeft pads the left side of a string.
+	PadLeft PadSide = 0
+
+	// PadRight pads the right side of a string.
+	PadRight PadSide = 1
+
+	// PadBoth pads both sides of a string. When the padding can't be evenly
+	// distributed, the right side receives the extra character.
+	PadBoth PadSide = 2
+)
+
+// mapString creates a new SeriesString by applying fn to each non-nil value.
+// Nil values remain nil.
+func (s *SeriesString) mapString(ctx context.Context, fn func(string) string, opts []Options) (*SeriesString, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	ss := NewSeriesString(s.name, &SeriesInit{Capacity: len(s.values)})
+
+	for _, rowVal := range s.values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			ss.values = append(ss.values, nil)
+			ss.nilCount++
+		} else {
+			cv := fn(*rowVal)
+			ss.values = append(ss.values, &cv)
+		}
+	}
+
+	return ss, nil
+}
+
+// mapInt64 creates a new SeriesInt64 by applying fn to each non-nil value.
+// Nil values remain nil.
+func (s *SeriesString) mapInt64(ctx context.Context, fn func(string) int64, opts []Options) (*SeriesInt64, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	si := NewSeriesInt64(s.name, &SeriesInit{Capacity: len(s.values)})
+
+	for _, rowVal := range s.values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			si.values = append(si.values, nil)
+			si.nilCount++
+		} else {
+			cv := fn(*rowVal)
+			si.values = append(si.values, &cv)
+		}
+	}
+
+	return si, nil
+}
+
+// mapBool creates a new SeriesInt64 containing 1 (true) or 0 (false) by applying fn to each non-nil value.
+// Nil values remain nil. The returned Series uses BoolValueFormatter.
+func (s *SeriesString) mapBool(ctx context.Context, fn func(string) bool, opts []Options) (*SeriesInt64, error) {
+	si, err := s.mapInt64(ctx, func(str string) int64 {
+		return int64(B(fn(str)))
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	si.SetValueToStringFormatter(BoolValueFormatter)
+	return si, nil
+}
+
+// Contains returns a SeriesInt64 indicating whether each value contains substr.
+// The returned Series uses BoolValueFormatter. Nil values remain nil.
+func (s *SeriesString) Contains(ctx context.Context, substr string, opts ...Options) (*SeriesInt64, error) {
+	return s.mapBool(ctx, func(str string) bool {
+		return strings.Contains(str, substr)
+	}, opts)
+}
+
+// HasPrefix returns a SeriesInt64 indicating whether each value begins with prefix.
+// The returned Series uses BoolValueFormatter. Nil values remain nil.
+func (s *SeriesString) HasPrefix(ctx context.Context, prefix string, opts ...Options) (*SeriesInt64, error) {
+	return s.mapBool(ctx, func(str string) bool {
+		return strings.HasPrefix(str, prefix)
+	}, opts)
+}
+
+// HasSuffix returns a SeriesInt64 indicating whether each value ends with suffix.
+// The returned Series uses BoolValueFormatter. Nil values remain nil.
+func (s *SeriesString) HasSuffix(ctx context.Context, suffix string, opts ...Options) (*SeriesInt64, error) {
+	return s.mapBool(ctx, func(str string) bool {
+		return strings.HasSuffix(str, suffix)
+	}, opts)
+}
+
+// Match returns a SeriesInt64 indicating whether each value contains any match of re.
+// The returned Series uses BoolValueFormatter. Nil values remain nil.
+func (s *SeriesString) Match(ctx context.Context, re *regexp.Regexp, opts ...Options) (*SeriesInt64, error) {
+	return s.mapBool(ctx, re.MatchString, opts)
+}
+
+// Extract returns a SeriesString containing the text of the first match of re for each value.
+// group determines which capture group is returned. A group of 0 returns the entire match.
+// Values that don't match are given a nil value.
+//
+// Example:
+//
+//  re := regexp.MustCompile(`(\d+)-(\d+)`)
+//  s := dataframe.NewSeriesString("range", nil, "10-20", "abc", nil)
+//
+//  upper, _ := s.Extract(ctx, re, 2)
+//  // Output: range: [ 20 NaN NaN ]
+//
+func (s *SeriesString) Extract(ctx context.Context, re *regexp.Regexp, group int, opts ...Options) (*SeriesString, error) {
+	if group < 0 || group > re.NumSubexp() {
+		return nil, fmt.Errorf("invalid capture group: %d", group)
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	ss := NewSeriesString(s.name, &SeriesInit{Capacity: len(s.values)})
+
+	for _, rowVal := range s.values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			ss.values = append(ss.values, nil)
+			ss.nilCount++
+			continue
+		}
+
+		idx := re.FindStringSubmatchIndex(*rowVal)
+		if idx == nil || idx[2*group] < 0 {
+			ss.values = append(ss.values, nil)
+			ss.nilCount++
+			continue
+		}
+
+		cv := (*rowVal)[idx[2*group]:idx[2*group+1]]
+		ss.values = append(ss.values, &cv)
+	}
+
+	return ss, nil
+}
+
+// ReplaceAll returns a SeriesString where all matches of re are replaced with repl.
+// Inside repl, $ signs are interpreted as in regexp.Expand. Nil values remain nil.
+//
+// See: https://golang.org/pkg/regexp/#Regexp.ReplaceAllString
+func (s *SeriesString) ReplaceAll(ctx context.Context, re *regexp.Regexp, repl string, opts ...Options) (*SeriesString, error) {
+	return s.mapString(ctx, func(str string) string {
+		return re.ReplaceAllString(str, repl)
+	}, opts)
+}
+
+// Split slices each value into substrings separated by sep. The i-th substring of each value
+// is stored in the i-th returned Series, which is named after the Series with a suffix of "_i".
+// n determines the maximum number of substrings (and hence Series). If n <= 0, all substrings are returned.
+// Values with fewer substrings are given a nil value in the remaining Series. Nil values remain nil.
+//
+// Example:
+//
+//  s := dataframe.NewSeriesString("name", nil, "John Smith", "Cher")
+//
+//  parts, _ := s.Split(ctx, " ", 2)
+//  // Output: [name_0: [ John Cher ] name_1: [ Smith NaN ]]
+//
+func (s *SeriesString) Split(ctx context.Context, sep string, n int, opts ...Options) ([]*SeriesString, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if n <= 0 {
+		n = -1
+	}
+
+	splits := make([][]string, 0, len(s.values))
+	var max int
+
+	for _, rowVal := range s.values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			splits = append(splits, nil)
+			continue
+		}
+
+		parts := strings.SplitN(*rowVal, sep, n)
+		if len(parts) > max {
+			max = len(parts)
+		}
+		splits = append(splits, parts)
+	}
+
+	out := make([]*SeriesString, 0, max)
+	for i := 0; i < max; i++ {
+		ss := NewSeriesString(fmt.Sprintf("%s_%d", s.name, i), &SeriesInit{Capacity: len(s.values)})
+
+		for _, parts := range splits {
+			if i < len(parts) {
+				ss.values = append(ss.values, &parts[i])
+			} else {
+				ss.values = append(ss.values, nil)
+				ss.nilCount++
+			}
+		}
+
+		out = append(out, ss)
+	}
+
+	return out, nil
+}
+
+// Upper returns a SeriesString with all values converted to upper case. Nil values remain nil.
+func (s *SeriesString) Upper(ctx context.Context, opts ...Options) (*SeriesString, error) {
+	return s.mapString(ctx, strings.ToUpper, opts)
+}
+
+// Lower returns a SeriesString with all values converted to lower case. Nil values remain nil.
+func (s *SeriesString) Lower(ctx context.Context, opts ...Options) (*SeriesString, error) {
+	return s.mapString(ctx, strings.ToLower, opts)
+}
+
+// Title returns a SeriesString where the first letter of each word is converted to title case
+// and the remaining letters to lower case. Nil values remain nil.
+func (s *SeriesString) Title(ctx context.Context, opts ...Options) (*SeriesString, error) {
+	return s.mapString(ctx, func(str string) string {
+		return strings.Title(strings.ToLower(str))
+	}, opts)
+}
+
+// Trim returns a SeriesString with all leading and trailing characters contained in cutset removed.
+// If cutset is blank, leading and trailing white space is removed. Nil values remain nil.
+func (s *SeriesString) Trim(ctx context.Context, cutset string, opts ...Options) (*SeriesString, error) {
+	if cutset == "" {
+		return s.mapString(ctx, strings.TrimSpace, opts)
+	}
+
+	return s.mapString(ctx, func(str string) string {
+		return strings.Trim(str, cutset)
+	}, opts)
+}
+
+// Len returns a SeriesInt64 containing the number of characters (runes) of each value. Nil values remain nil.
+func (s *SeriesString) Len(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(str string) int64 {
+		return int64(utf8.RuneCountInString(str))
+	}, opts)
+}
+
+// Pad returns a SeriesString where each value is padded with fillchar until it contains
+// width characters (runes). Values that are already long enough are unchanged. Nil values remain nil.
+func (s *SeriesString) Pad(ctx context.Context, width int, side PadSide, fillchar rune, opts ...Options) (*SeriesString, error) {
+	return s.mapString(ctx, func(str string) string {
+		n := width - utf8.RuneCountInString(str)
+		if n <= 0 {
+			return str
+		}
+
+		switch side {
+		case PadRight:
+			return str + strings.Repeat(string(fillchar), n)
+		case PadBoth:
+			left := n / 2
+			return strings.Repeat(string(fillchar), left) + str + strings.Repeat(string(fillchar), n-left)
+		default:
+			return strings.Repeat(string(fillchar), n) + str
+		}
+	}, opts)
+}
+
+// Slice returns a SeriesString containing the characters (runes) of each value within the range r.
+// Unlike other uses of Range, the limits are clamped to the length of each value. If no characters
+// fall within the range, the value becomes an empty string. Nil values remain nil.
+//
+// Example:
+//
+//  s := dataframe.NewSeriesString("code", nil, "AU-2000", "NZ-6011")
+//
+//  country, _ := s.Slice(ctx, dataframe.RangeFinite(0, 1))
+//  // Output: code: [ AU NZ ]
+//
+//  last, _ := s.Slice(ctx, dataframe.RangeFinite(-2))
+//  // Output: code: [ 00 11 ]
+//
+func (s *SeriesString) Slice(ctx context.Context, r Range, opts ...Options) (*SeriesString, error) {
+	return s.mapString(ctx, func(str string) string {
+		runes := []rune(str)
+		length := len(runes)
+
+		start, end := 0, length-1
+		if r.Start != nil {
+			start = *r.Start
+			if start < 0 {
+				start = length + start
+			}
+		}
+		if r.End != nil {
+			end = *r.End
+			if end < 0 {
+				end = length + end
+			}
+		}
+
+		if start < 0 {
+			start = 0
+		}
+		if end > length-1 {
+			end = length - 1
+		}
+
+		if start > end {
+			return ""
+		}
+		return string(runes[start : end+1])
+	}, opts)
+}
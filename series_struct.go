@@ -0,0 +1,1033 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// SeriesStruct is a series of data where each value is a struct composed of named fields.
+// Each field is stored in its own Series (which can also be a SeriesStruct), so the hierarchy of
+// nested data is preserved.
+//
+// The value of each row is a map[string]interface{} containing the value of each field (or nil).
+// The fields of a nil row contain nil values.
+type SeriesStruct struct {
+	valFormatter   ValueToStringFormatter
+	isEqualFunc    IsEqualFunc
+	isLessThanFunc IsLessThanFunc
+
+	lock   sync.RWMutex
+	name   string
+	fields []Series
+	nils   []bool // true when the row is nil
+}
+
+// NewSeriesStruct creates a new series composed of the given field Series. The fields must have unique names
+// and the same number of rows. The values of the fields form the (non-nil) rows of the series.
+// When init is provided, nil rows are appended until the series contains init.Size rows.
+//
+// Example:
+//
+//  s := dataframe.NewSeriesStruct("address", nil,
+//     dataframe.NewSeriesString("city", nil, "Sydney", "Melbourne"),
+//     dataframe.NewSeriesInt64("postcode", nil, 2000, 3000),
+//  )
+//
+func NewSeriesStruct(name string, init *SeriesInit, fields ...Series) *SeriesStruct {
+
+	if len(fields) == 0 {
+		panic("no fields provided")
+	}
+
+	var n int
+	names := map[string]struct{}{}
+
+	for idx, f := range fields {
+		fname := f.Name()
+		if _, exists := names[fname]; exists {
+			panic(&SeriesError{Series: fname, Err: errors.New("names of fields must be unique")})
+		}
+		names[fname] = struct{}{}
+
+		if idx == 0 {
+			n = f.NRows()
+		} else if f.NRows() != n {
+			panic(&SeriesError{Series: fname, Err: errors.New("different number of rows in fields")})
+		}
+	}
+
+	s := &SeriesStruct{
+		valFormatter: DefaultValueFormatter,
+		isEqualFunc:  DefaultIsEqualFunc,
+		name:         name,
+		fields:       fields,
+		nils:         make([]bool, n),
+	}
+	s.isLessThanFunc = s.structLess
+
+	if init != nil {
+		for row := n; row < init.Size; row++ {
+			s.insert(row, nil)
+		}
+	}
+
+	return s
+}
+
+// NewSeries creates a new initialized SeriesStruct with the same fields (without values).
+// All fields must implement the NewSerieser interface.
+func (s *SeriesStruct) NewSeries(name string, init *SeriesInit) Series {
+	var fieldInit *SeriesInit
+	if init != nil {
+		fieldInit = &SeriesInit{Capacity: init.Capacity}
+	}
+
+	fields := make([]Series, 0, len(s.fields))
+	for _, f := range s.fields {
+		nser, ok := f.(NewSerieser)
+		if !ok {
+			panic(&SeriesError{Series: f.Name(dontLock), Err: errors.New("field must implement NewSerieser interface")})
+		}
+		fields = append(fields, nser.NewSeries(f.Name(dontLock), fieldInit))
+	}
+
+	return NewSeriesStruct(name, init, fields...)
+}
+
+// Fields returns the Series of each field.
+//
+// NOTE: The fields must not be modified in a way that changes their number of rows.
+func (s *SeriesStruct) Fields() []Series {
+	return s.fields
+}
+
+// Field returns the Series of the field with the given name.
+func (s *SeriesStruct) Field(name string) (Series, error) {
+	if f := s.field(name); f != nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown field: %s", name)
+}
+
+func (s *SeriesStruct) field(name string) Series {
+	for _, f := range s.fields {
+		if f.Name(dontLock) == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// structLess compares 2 structs field by field using the Series of each field. Nil values are less than
+// other values.
+func (s *SeriesStruct) structLess(a, b interface{}) bool {
+	x, y := a.(map[string]interface{}), b.(map[string]interface{})
+
+	for _, f := range s.fields {
+		name := f.Name(dontLock)
+		xv, yv := x[name], y[name]
+
+		if xv == nil || yv == nil {
+			if xv == nil && yv == nil {
+				continue
+			}
+			return xv == nil
+		}
+
+		if f.IsEqualFunc(xv, yv) {
+			continue
+		}
+		return f.IsLessThanFunc(xv, yv)
+	}
+	return false
+}
+
+// Name returns the series name.
+func (s *SeriesStruct) Name(opts ...Options) string {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.name
+}
+
+// Rename renames the series.
+func (s *SeriesStruct) Rename(n string, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.name = n
+}
+
+// Type returns the type of data the series holds.
+// eg. "struct{city string, postcode int64}"
+func (s *SeriesStruct) Type() string {
+	fields := make([]string, 0, len(s.fields))
+	for _, f := range s.fields {
+		fields = append(fields, f.Name(dontLock)+" "+f.Type())
+	}
+	return "struct{" + strings.Join(fields, ", ") + "}"
+}
+
+// NRows returns how many rows the series contains.
+func (s *SeriesStruct) NRows(opts ...Options) int {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return len(s.nils)
+}
+
+// Value returns the value of a particular row.
+// The return value could be nil or a map[string]interface{}.
+func (s *SeriesStruct) Value(row int, opts ...Options) interface{} {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if s.nils[row] {
+		return nil
+	}
+
+	out := make(map[string]interface{}, len(s.fields))
+	for _, f := range s.fields {
+		out[f.Name(dontLock)] = f.Value(row, dontLock)
+	}
+	return out
+}
+
+// ValueString returns a string representation of a
+// particular row. The string representation is defined
+// by the function set in SetValueToStringFormatter.
+// By default, a nil value is returned as "NaN".
+func (s *SeriesStruct) ValueString(row int, opts ...Options) string {
+	return s.valFormatter(s.Value(row, opts...))
+}
+
+// Prepend is used to set a value to the beginning of the
+// series. val can be a map[string]interface{} or nil. Nil
+// represents the absence of a value.
+func (s *SeriesStruct) Prepend(val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.insert(0, val)
+}
+
+// Append is used to set a value to the end of the series.
+// val can be a map[string]interface{} or nil. Nil represents
+// the absence of a value.
+func (s *SeriesStruct) Append(val interface{}, opts ...Options) int {
+	var locked bool
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		locked = true
+	}
+
+	row := s.NRows(Options{DontLock: locked})
+	s.insert(row, val)
+	return row
+}
+
+// Insert is used to set a value at an arbitrary row in
+// the series. All existing values from that row onwards
+// are shifted by 1. val can be a map[string]interface{} or nil.
+// Nil represents the absence of a value.
+func (s *SeriesStruct) Insert(row int, val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.insert(row, val)
+}
+
+func (s *SeriesStruct) insert(row int, val interface{}) {
+	m, err := s.checkValue(val)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, f := range s.fields {
+		if m == nil {
+			f.Insert(row, nil, dontLock)
+		} else {
+			f.Insert(row, m[f.Name(dontLock)], dontLock)
+		}
+	}
+
+	s.nils = append(s.nils, false)
+	copy(s.nils[row+1:], s.nils[row:])
+	s.nils[row] = m == nil
+}
+
+// checkValue returns an error if val contains an unknown field.
+func (s *SeriesStruct) checkValue(val interface{}) (map[string]interface{}, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%T is not a map[string]interface{}", val)
+	}
+
+	for k := range m {
+		if s.field(k) == nil {
+			return nil, fmt.Errorf("unknown field: %s", k)
+		}
+	}
+	return m, nil
+}
+
+// Remove is used to delete the value of a particular row.
+func (s *SeriesStruct) Remove(row int, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	for _, f := range s.fields {
+		f.Remove(row, dontLock)
+	}
+
+	s.nils = append(s.nils[:row], s.nils[row+1:]...)
+}
+
+// Reset is used clear all data contained in the Series.
+func (s *SeriesStruct) Reset(opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	for _, f := range s.fields {
+		f.Reset(dontLock)
+	}
+
+	s.nils = []bool{}
+}
+
+// Update is used to update the value of a particular row.
+// val can be a map[string]interface{} or nil. Nil represents
+// the absence of a value. Fields that are not contained in val are set to nil.
+func (s *SeriesStruct) Update(row int, val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	m, err := s.checkValue(val)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, f := range s.fields {
+		if m == nil {
+			f.Update(row, nil, dontLock)
+		} else {
+			f.Update(row, m[f.Name(dontLock)], dontLock)
+		}
+	}
+
+	s.nils[row] = m == nil
+}
+
+// ValuesIterator will return a function that can be used to iterate through all the values.
+func (s *SeriesStruct) ValuesIterator(opts ...ValuesOptions) func() (*int, interface{}, int) {
+
+	var (
+		row  int
+		step int = 1
+	)
+
+	var dontReadLock bool
+
+	if len(opts) > 0 {
+		dontReadLock = opts[0].DontReadLock
+
+		row = opts[0].InitialRow
+		if row < 0 {
+			row = len(s.nils) + row
+		}
+		if opts[0].Step != 0 {
+			step = opts[0].Step
+		}
+	}
+
+	initial := row
+
+	return func() (*int, interface{}, int) {
+		if !dontReadLock {
+			s.lock.RLock()
+			defer s.lock.RUnlock()
+		}
+
+		var t int
+		if step > 0 {
+			t = (len(s.nils)-initial-1)/step + 1
+		} else {
+			t = -initial/step + 1
+		}
+
+		if row > len(s.nils)-1 || row < 0 {
+			// Don't iterate further
+			return nil, nil, t
+		}
+
+		out := s.Value(row, dontLock)
+		row = row + step
+		return &[]int{row - step}[0], out, t
+	}
+}
+
+// SetValueToStringFormatter is used to set a function
+// to convert the value of a particular row to a string
+// representation.
+func (s *SeriesStruct) SetValueToStringFormatter(f ValueToStringFormatter) {
+	if f == nil {
+		s.valFormatter = DefaultValueFormatter
+		return
+	}
+	s.valFormatter = f
+}
+
+// IsEqualFunc returns true if a is equal to b.
+func (s *SeriesStruct) IsEqualFunc(a, b interface{}) bool {
+
+	if s.isEqualFunc == nil {
+		panic(errors.New("IsEqualFunc not set"))
+	}
+
+	return s.isEqualFunc(a, b)
+}
+
+// IsLessThanFunc returns true if a is less than b.
+// By default, structs are compared field by field (in order) using the Series of each field.
+func (s *SeriesStruct) IsLessThanFunc(a, b interface{}) bool {
+
+	if s.isLessThanFunc == nil {
+		panic(errors.New("IsLessThanFunc not set"))
+	}
+
+	return s.isLessThanFunc(a, b)
+}
+
+// SetIsEqualFunc sets a function which can be used to determine
+// if 2 values in the series are equal.
+func (s *SeriesStruct) SetIsEqualFunc(f IsEqualFunc) {
+	if f == nil {
+		// Return to default
+		s.isEqualFunc = DefaultIsEqualFunc
+	} else {
+		s.isEqualFunc = f
+	}
+}
+
+// SetIsLessThanFunc sets a function which can be used to determine
+// if a value is less than another in the series.
+func (s *SeriesStruct) SetIsLessThanFunc(f IsLessThanFunc) {
+	if f == nil {
+		// Return to default
+		s.isLessThanFunc = s.structLess
+	} else {
+		s.isLessThanFunc = f
+	}
+}
+
+// Sort will sort the series.
+// It will return true if sorting was completed or false when the context is canceled.
+func (s *SeriesStruct) Sort(ctx context.Context, opts ...SortOptions) (completed bool) {
+
+	defer func() {
+		if x := recover(); x != nil {
+			completed = false
+		}
+	}()
+
+	if len(opts) == 0 {
+		opts = append(opts, SortOptions{})
+	}
+
+	if !opts[0].DontLock {
+		s.Lock()
+		defer s.Unlock()
+	}
+
+	vals := make([]interface{}, 0, len(s.nils))
+	for row := range s.nils {
+		vals = append(vals, s.Value(row, dontLock))
+	}
+
+	sortFunc := func(i, j int) (ret bool) {
+		if err := ctx.Err(); err != nil {
+			panic(err)
+		}
+
+		defer func() {
+			if opts[0].Desc {
+				ret = !ret
+			}
+		}()
+
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := vals[i] == nil, vals[j] == nil
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
+		left := vals[i]
+		right := vals[j]
+
+		if left == nil {
+			// left is nil (regardless of right)
+			return true
+		}
+
+		if right == nil {
+			// left has value and right is nil
+			return false
+		}
+		// Both are not nil
+		return s.isLessThanFunc(left, right)
+	}
+
+	if opts[0].Stable {
+		sort.SliceStable(vals, sortFunc)
+	} else {
+		sort.Slice(vals, sortFunc)
+	}
+
+	// Store sorted values
+	for row, val := range vals {
+		for _, f := range s.fields {
+			if val == nil {
+				f.Update(row, nil, dontLock)
+			} else {
+				f.Update(row, val.(map[string]interface{})[f.Name(dontLock)], dontLock)
+			}
+		}
+		s.nils[row] = val == nil
+	}
+
+	return true
+}
+
+// Swap is used to swap 2 values based on their row position.
+func (s *SeriesStruct) Swap(row1, row2 int, opts ...Options) {
+	if row1 == row2 {
+		return
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	for _, f := range s.fields {
+		f.Swap(row1, row2, dontLock)
+	}
+
+	s.nils[row1], s.nils[row2] = s.nils[row2], s.nils[row1]
+}
+
+// Lock will lock the Series allowing you to directly manipulate
+// the underlying slice with confidence.
+func (s *SeriesStruct) Lock() {
+	s.lock.Lock()
+}
+
+// Unlock will unlock the Series that was previously locked.
+func (s *SeriesStruct) Unlock() {
+	s.lock.Unlock()
+}
+
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesStruct) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesStruct) RUnlock() {
+	s.lock.RUnlock()
+}
+
+// Copy will create a new copy of the series.
+// It is recommended that you lock the Series before attempting
+// to Copy.
+func (s *SeriesStruct) Copy(r ...Range) Series {
+
+	fields := make([]Series, 0, len(s.fields))
+
+	if len(s.nils) == 0 {
+		for _, f := range s.fields {
+			fields = append(fields, f.Copy())
+		}
+		return s.newFrom(fields, []bool{})
+	}
+
+	if len(r) == 0 {
+		r = append(r, Range{})
+	}
+
+	start, end, err := r[0].Limits(len(s.nils))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, f := range s.fields {
+		fields = append(fields, f.Copy(RangeFinite(start, end)))
+	}
+
+	// Copy slice
+	x := s.nils[start : end+1]
+	return s.newFrom(fields, append(x[:0:0], x...))
+}
+
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// Fields that don't implement the Viewer interface are copied.
+func (s *SeriesStruct) View(r Range) Series {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if len(s.nils) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.nils))
+	if err != nil {
+		panic(err)
+	}
+
+	rng := RangeFinite(start, end)
+
+	fields := make([]Series, 0, len(s.fields))
+	for _, f := range s.fields {
+		if v, ok := f.(Viewer); ok {
+			fields = append(fields, v.View(rng))
+		} else {
+			fields = append(fields, f.Copy(rng))
+		}
+	}
+
+	x := s.nils[start : end+1]
+	return s.newFrom(fields, append(x[:0:0], x...))
+}
+
+func (s *SeriesStruct) newFrom(fields []Series, nils []bool) *SeriesStruct {
+	return &SeriesStruct{
+		valFormatter:   s.valFormatter,
+		isEqualFunc:    s.isEqualFunc,
+		isLessThanFunc: s.isLessThanFunc,
+
+		name:   s.name,
+		fields: fields,
+		nils:   nils,
+	}
+}
+
+// Table will produce the Series in a table.
+func (s *SeriesStruct) Table(opts ...TableOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, TableOptions{R: &Range{}})
+	}
+
+	if !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	data := [][]string{}
+
+	headers := []string{"", s.name} // row header is blank
+	footers := []string{fmt.Sprintf("%dx%d", len(s.nils), 1), s.Type()}
+
+	if len(s.nils) > 0 {
+
+		start, end, err := opts[0].R.Limits(len(s.nils))
+		if err != nil {
+			panic(err)
+		}
+
+		for row := start; row <= end; row++ {
+			sVals := []string{fmt.Sprintf("%d:", row), s.ValueString(row, dontLock)}
+			data = append(data, sVals)
+		}
+
+	}
+
+	var buf bytes.Buffer
+
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(headers)
+	for _, v := range data {
+		table.Append(v)
+	}
+	table.SetFooter(footers)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+
+	table.Render()
+
+	return buf.String()
+}
+
+// String implements the fmt.Stringer interface. It does not lock the Series.
+func (s *SeriesStruct) String() string {
+
+	count := len(s.nils)
+
+	out := s.name + ": [ "
+
+	if count > 6 {
+		idx := []int{0, 1, 2, count - 3, count - 2, count - 1}
+		for j, row := range idx {
+			if j == 3 {
+				out = out + "... "
+			}
+			out = out + s.ValueString(row, dontLock) + " "
+		}
+		return out + "]"
+	}
+
+	for row := range s.nils {
+		out = out + s.ValueString(row, dontLock) + " "
+	}
+	return out + "]"
+}
+
+// ContainsNil will return whether or not the series contains any nil values.
+// A row is only nil if the struct itself is nil (not when its fields contain nil values).
+func (s *SeriesStruct) ContainsNil(opts ...Options) bool {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	for _, isNil := range s.nils {
+		if isNil {
+			return true
+		}
+	}
+	return false
+}
+
+// NilCount will return how many nil values are in the series.
+// A row is only nil if the struct itself is nil (not when its fields contain nil values).
+func (s *SeriesStruct) NilCount(opts ...NilCountOptions) (int, error) {
+
+	var (
+		ctx  context.Context = context.Background()
+		r                    = &Range{}
+		stop bool
+	)
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	if len(opts) > 0 {
+		if opts[0].Ctx != nil {
+			ctx = opts[0].Ctx
+		}
+		if opts[0].R != nil {
+			r = opts[0].R
+		}
+		stop = opts[0].StopAtOneNil
+	}
+
+	if len(s.nils) == 0 {
+		return 0, nil
+	}
+
+	start, end, err := r.Limits(len(s.nils))
+	if err != nil {
+		return 0, err
+	}
+
+	var nilCount int
+
+	for i := start; i <= end; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if s.nils[i] {
+
+			if stop {
+				return 1, nil
+			}
+
+			nilCount++
+		}
+	}
+
+	return nilCount, nil
+}
+
+// IsEqual returns true if s2's values are equal to s.
+func (s *SeriesStruct) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOptions) (bool, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	// Check type
+	ss, ok := s2.(*SeriesStruct)
+	if !ok || s.Type() != ss.Type() {
+		return false, nil
+	}
+
+	// Check number of values
+	if len(s.nils) != len(ss.nils) {
+		return false, nil
+	}
+
+	// Check name
+	if len(opts) != 0 && opts[0].CheckName {
+		if s.name != ss.name {
+			return false, nil
+		}
+	}
+
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
+	// Check values
+	for i, isNil := range s.nils {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		if isNil != ss.nils[i] {
+			return false, nil
+		}
+	}
+
+	for i, f := range s.fields {
+		eq, err := f.IsEqual(ctx, ss.fields[i], IsEqualOptions{DontLock: true})
+		if !eq || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+type seriesStructBinary struct {
+	Name      string
+	Fields    []seriesBinary
+	Nils      []int
+	Formatter string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+//
+// NOTE: The Series of each field must be registered using RegisterSeries.
+// The IsEqualFunc and IsLessThanFunc are not preserved.
+func (s *SeriesStruct) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	out := seriesStructBinary{
+		Name:      s.name,
+		Fields:    make([]seriesBinary, 0, len(s.fields)),
+		Nils:      []int{},
+		Formatter: ValueFormatterName(s.valFormatter),
+	}
+
+	for _, f := range s.fields {
+		typ, exists := registeredName(f)
+		if !exists {
+			return nil, &SeriesError{Series: f.Name(dontLock), Err: fmt.Errorf("%T is not registered", f)}
+		}
+
+		data, err := f.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, &SeriesError{Series: f.Name(dontLock), Err: err}
+		}
+		out.Fields = append(out.Fields, seriesBinary{Type: typ, Data: data})
+	}
+
+	for row, isNil := range s.nils {
+		if isNil {
+			out.Nils = append(out.Nils, row)
+		}
+	}
+
+	return gobEncode(out)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesStruct) UnmarshalBinary(data []byte) error {
+	var in seriesStructBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	fields := make([]Series, 0, len(in.Fields))
+	for idx, fb := range in.Fields {
+		f, exists := newRegistered(fb.Type)
+		if !exists {
+			return fmt.Errorf("field: %d: %s is not registered", idx, fb.Type)
+		}
+
+		if err := f.(encoding.BinaryUnmarshaler).UnmarshalBinary(fb.Data); err != nil {
+			return fmt.Errorf("field: %d: %w", idx, err)
+		}
+		fields = append(fields, f)
+	}
+
+	return s.set(in.Name, in.Formatter, fields, in.Nils)
+}
+
+// set replaces the fields after they have been decoded.
+func (s *SeriesStruct) set(name, formatter string, fields []Series, nilRows []int) error {
+	if len(fields) == 0 {
+		return errors.New("no fields")
+	}
+
+	n, err := checkSeries(fields)
+	if err != nil {
+		return err
+	}
+
+	nils := make([]bool, n)
+	for _, row := range nilRows {
+		if row < 0 || row >= n {
+			return fmt.Errorf("invalid nil row: %d", row)
+		}
+		nils[row] = true
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.fields = fields
+	s.nils = nils
+	s.valFormatter = LookupValueFormatter(formatter)
+	s.isEqualFunc = DefaultIsEqualFunc
+	s.isLessThanFunc = s.structLess
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The fields are encoded using the JSON representation
+// of their Series. The values are encoded as true, or null for a nil row.
+//
+// NOTE: The Series of each field must be registered using RegisterSeries and implement json.Marshaler.
+func (s *SeriesStruct) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	fields := make([]childJSON, 0, len(s.fields))
+	for _, f := range s.fields {
+		typ, exists := registeredName(f)
+		if !exists {
+			return nil, &SeriesError{Series: f.Name(dontLock), Err: fmt.Errorf("%T is not registered", f)}
+		}
+
+		m, ok := f.(json.Marshaler)
+		if !ok {
+			return nil, &SeriesError{Series: f.Name(dontLock), Err: fmt.Errorf("%T does not implement json.Marshaler", f)}
+		}
+
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return nil, &SeriesError{Series: f.Name(dontLock), Err: err}
+		}
+		fields = append(fields, childJSON{Series: typ, Data: data})
+	}
+
+	vals := make([]interface{}, 0, len(s.nils))
+	for _, isNil := range s.nils {
+		if isNil {
+			vals = append(vals, nil)
+		} else {
+			vals = append(vals, true)
+		}
+	}
+
+	return marshalSeriesJSON(seriesJSON{
+		Name:      s.name,
+		Type:      s.Type(),
+		Formatter: ValueFormatterName(s.valFormatter),
+		Fields:    fields,
+	}, vals)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesStruct) UnmarshalJSON(data []byte) error {
+	var sj seriesJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return err
+	}
+
+	fields := make([]Series, 0, len(sj.Fields))
+	for idx, fj := range sj.Fields {
+		f, exists := newRegistered(fj.Series)
+		if !exists {
+			return fmt.Errorf("field: %d: %s is not registered", idx, fj.Series)
+		}
+
+		u, ok := f.(json.Unmarshaler)
+		if !ok {
+			return fmt.Errorf("field: %d: %T does not implement json.Unmarshaler", idx, f)
+		}
+
+		if err := u.UnmarshalJSON(fj.Data); err != nil {
+			return fmt.Errorf("field: %d: %w", idx, err)
+		}
+		fields = append(fields, f)
+	}
+
+	if typ := (&SeriesStruct{fields: fields}).Type(); sj.Type != typ {
+		return fmt.Errorf("wrong type: expected: %s got: %s", typ, sj.Type)
+	}
+
+	vals := []*bool{}
+	if len(sj.Values) > 0 {
+		if err := json.Unmarshal(sj.Values, &vals); err != nil {
+			return err
+		}
+	}
+
+	nilRows := []int{}
+	for row, v := range vals {
+		if v == nil {
+			nilRows = append(nilRows, row)
+		}
+	}
+
+	if len(fields) > 0 && len(vals) != fields[0].NRows(dontLock) {
+		return fmt.Errorf("expected %d values but got %d", fields[0].NRows(dontLock), len(vals))
+	}
+
+	return s.set(sj.Name, sj.Formatter, fields, nilRows)
+}
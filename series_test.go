@@ -5,6 +5,8 @@ package dataframe
 import (
 	"context"
 	"fmt"
+	"math"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -469,6 +471,69 @@ func TestSeriesCopy(t *testing.T) {
 		}
 	}
 
+	// SeriesTime retains its Layout
+	st := NewSeriesTime("test", nil, time.Now(), nil)
+	st.Layout = time.RFC3339
+
+	empty := NewSeriesTime("test", nil)
+	empty.Layout = time.RFC3339
+
+	for _, cp := range []Series{st.Copy(), st.Copy(RangeFinite(1)), empty.Copy()} {
+		if layout := cp.(*SeriesTime).Layout; layout != time.RFC3339 {
+			t.Errorf("wrong val: expected: %v actual: %v", time.RFC3339, layout)
+		}
+	}
+
+}
+
+func TestSeriesView(t *testing.T) {
+	ctx := context.Background()
+
+	tRef := time.Date(2017, 1, 1, 5, 30, 12, 0, time.UTC)
+
+	// Create new series
+	init := []Series{
+		NewSeriesFloat64("test", nil, 1, nil, 2, 3),
+		NewSeriesInt64("test", nil, 1, nil, 2, 3),
+		NewSeriesString("test", nil, "1", nil, "2", "3"),
+		NewSeriesTime("test", nil, tRef, nil, tRef.Add(time.Hour), tRef.Add(2*time.Hour)),
+		NewSeriesDuration("test", nil, time.Second, nil, 2*time.Second, 3*time.Second),
+		NewSeriesMixed("test", nil, 1, nil, 2, 3),
+		NewSeriesGeneric("test", civil.Date{}, nil, civil.Date{Year: 2018, Month: time.May, Day: 1}, nil, civil.Date{Year: 2018, Month: time.May, Day: 2}, civil.Date{Year: 2018, Month: time.May, Day: 3}),
+	}
+
+	for i := range init {
+		s := init[i]
+		orig := s.Copy()
+
+		view := s.(Viewer).View(Range{Start: &[]int{1}[0], End: &[]int{2}[0]})
+
+		// View contains rows 1 & 2
+		expected := s.Copy(Range{Start: &[]int{1}[0], End: &[]int{2}[0]})
+		if eq, _ := view.IsEqual(ctx, expected); !eq {
+			t.Errorf("%s: wrong val: expected: %v actual: %v", s.Type(), expected, view)
+		}
+
+		if nc, _ := view.NilCount(); nc != 1 {
+			t.Errorf("%s: wrong nil count: expected: %v actual: %v", s.Type(), 1, nc)
+		}
+
+		// Modifying the view does not modify s
+		view.Update(0, s.Value(0))
+		view.Append(s.Value(3))
+		view.Swap(0, 1)
+		if eq, _ := s.IsEqual(ctx, orig); !eq {
+			t.Errorf("%s: wrong val: expected: %v actual: %v", s.Type(), orig, s)
+		}
+
+		// Modifying s does not modify the view
+		view = s.(Viewer).View(Range{End: &[]int{1}[0]})
+		s.Update(1, s.Value(0))
+		s.Remove(0)
+		if eq, _ := view.IsEqual(ctx, orig.Copy(Range{End: &[]int{1}[0]})); !eq {
+			t.Errorf("%s: wrong val: expected: %v actual: %v", s.Type(), orig.Copy(Range{End: &[]int{1}[0]}), view)
+		}
+	}
 }
 
 func TestToSeriesString(t *testing.T) {
@@ -560,3 +625,392 @@ func TestStopAtOneNil(t *testing.T) {
 		}
 	}
 }
+
+func TestSeriesStringOps(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesString("code", nil, " au-2000 ", nil, "nz-6011")
+
+	trimmed, err := s.Trim(ctx, "")
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	upper, _ := trimmed.Upper(ctx)
+	country, _ := upper.Slice(ctx, RangeFinite(0, 1))
+	postcode, _ := upper.Extract(ctx, regexp.MustCompile(`-(\d+)$`), 1)
+	isAU, _ := upper.HasPrefix(ctx, "AU")
+	length, _ := trimmed.Len(ctx)
+	padded, _ := country.Pad(ctx, 4, PadBoth, '*')
+	parts, _ := trimmed.Split(ctx, "-", 0)
+
+	tests := []struct {
+		s        Series
+		expected []interface{}
+	}{
+		{upper, []interface{}{"AU-2000", nil, "NZ-6011"}},
+		{country, []interface{}{"AU", nil, "NZ"}},
+		{postcode, []interface{}{"2000", nil, "6011"}},
+		{isAU, []interface{}{int64(1), nil, int64(0)}},
+		{length, []interface{}{int64(7), nil, int64(7)}},
+		{padded, []interface{}{"*AU*", nil, "*NZ*"}},
+		{parts[1], []interface{}{"2000", nil, "6011"}},
+	}
+
+	for i, tc := range tests {
+		for row, expected := range tc.expected {
+			actual := tc.s.Value(row)
+			if !cmp.Equal(expected, actual) {
+				t.Errorf("%d: wrong val: expected: %T %v actual: %T %v", i, expected, expected, actual, actual)
+			}
+		}
+	}
+
+	if isAU.ValueString(0) != "true" {
+		t.Errorf("wrong val: expected: true actual: %v", isAU.ValueString(0))
+	}
+}
+
+func TestSeriesTimeComponents(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesTime("date", nil,
+		time.Date(2020, time.February, 29, 13, 5, 0, 0, time.UTC),
+		nil,
+		time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC),
+	)
+
+	year, _ := s.Year(ctx)
+	quarter, _ := s.Quarter(ctx)
+	month, _ := s.Month(ctx)
+	isoWeek, _ := s.ISOWeek(ctx)
+	dayOfYear, _ := s.DayOfYear(ctx)
+	day, _ := s.Day(ctx)
+	weekday, _ := s.Weekday(ctx)
+	hour, _ := s.Hour(ctx)
+	formatted, _ := s.Format(ctx, "2006-01-02")
+
+	tests := []struct {
+		s        Series
+		expected []interface{}
+	}{
+		{year, []interface{}{int64(2020), nil, int64(2021)}},
+		{quarter, []interface{}{int64(1), nil, int64(1)}},
+		{month, []interface{}{int64(2), nil, int64(1)}},
+		{isoWeek, []interface{}{int64(9), nil, int64(53)}},
+		{dayOfYear, []interface{}{int64(60), nil, int64(3)}},
+		{day, []interface{}{int64(29), nil, int64(3)}},
+		{weekday, []interface{}{int64(6), nil, int64(0)}},
+		{hour, []interface{}{int64(13), nil, int64(0)}},
+		{formatted, []interface{}{"2020-02-29", nil, "2021-01-03"}},
+	}
+
+	for i, tc := range tests {
+		for row, expected := range tc.expected {
+			actual := tc.s.Value(row)
+			if !cmp.Equal(expected, actual) {
+				t.Errorf("%d: wrong val: expected: %T %v actual: %T %v", i, expected, expected, actual, actual)
+			}
+		}
+	}
+}
+
+func TestSeriesTimeLocation(t *testing.T) {
+	ctx := context.Background()
+
+	loc, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	s := NewSeriesTime("time", nil, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+
+	converted, err := s.TZConvert(ctx, loc)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	localized, err := s.TZLocalize(ctx, loc)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	// Values inserted later are converted to the Location
+	converted.Append(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		s        Series
+		expected []interface{}
+	}{
+		{converted, []interface{}{time.Date(2020, 1, 1, 11, 0, 0, 0, loc), nil, time.Date(2020, 7, 1, 10, 0, 0, 0, loc)}},
+		{localized, []interface{}{time.Date(2020, 1, 1, 0, 0, 0, 0, loc), nil}},
+		{converted.Copy(), []interface{}{time.Date(2020, 1, 1, 11, 0, 0, 0, loc), nil, time.Date(2020, 7, 1, 10, 0, 0, 0, loc)}},
+	}
+
+	for i, tc := range tests {
+		if tc.s.(*SeriesTime).Location != loc {
+			t.Errorf("%d: wrong location: expected: %v actual: %v", i, loc, tc.s.(*SeriesTime).Location)
+		}
+
+		for row, expected := range tc.expected {
+			actual := tc.s.Value(row)
+			if !cmp.Equal(expected, actual) {
+				t.Errorf("%d: wrong val: expected: %T %v actual: %T %v", i, expected, expected, actual, actual)
+			}
+		}
+	}
+}
+
+func TestSeriesDuration(t *testing.T) {
+	ctx := context.Background()
+
+	start := NewSeriesTime("start", nil,
+		time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+		nil,
+		time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+	)
+
+	// Strings can be in Go or ISO 8601 format
+	sd := NewSeriesDuration("duration", nil, "1h30m", "PT2H", "P1D", nil)
+
+	end, err := start.Add(ctx, sd)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	diff, err := end.Sub(ctx, start)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := []interface{}{90 * time.Minute, 2 * time.Hour, nil, nil}
+	for row, e := range expected {
+		if !cmp.Equal(e, diff.Value(row)) {
+			t.Errorf("wrong val: expected: %v actual: %v", e, diff.Value(row))
+		}
+	}
+
+	sum, _ := sd.Sum(ctx)
+	mean, _ := sd.Mean(ctx)
+	median, _ := sd.Median(ctx)
+
+	if sum != 27*time.Hour+30*time.Minute {
+		t.Errorf("wrong sum: %v", sum)
+	}
+	if mean != 9*time.Hour+10*time.Minute {
+		t.Errorf("wrong mean: %v", mean)
+	}
+	if median != 2*time.Hour {
+		t.Errorf("wrong median: %v", median)
+	}
+
+	sd.SetValueToStringFormatter(ISODurationValueFormatter)
+	if sd.ValueString(0) != "PT1H30M" || sd.ValueString(2) != "PT24H" {
+		t.Errorf("wrong formatting: %v %v", sd.ValueString(0), sd.ValueString(2))
+	}
+
+	for _, invalid := range []string{"P", "PT", "P1M", "1x"} {
+		if _, err := ParseDuration(invalid); err == nil {
+			t.Errorf("expected error for: %s", invalid)
+		}
+	}
+}
+
+func TestSeriesIsEqualApprox(t *testing.T) {
+	ctx := context.Background()
+
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		s1       Series
+		s2       Series
+		opts     IsEqualOptions
+		expected bool
+	}{
+		{NewSeriesFloat64("s", nil, 1.0, nil), NewSeriesFloat64("s", nil, 1.0+1e-10, nil), IsEqualOptions{}, false},
+		{NewSeriesFloat64("s", nil, 1.0, nil), NewSeriesFloat64("s", nil, 1.0+1e-10, nil), IsEqualOptions{AbsTol: 1e-9}, true},
+		{NewSeriesFloat64("s", nil, 100.0), NewSeriesFloat64("s", nil, 101.0), IsEqualOptions{RelTol: 0.001}, false},
+		{NewSeriesFloat64("s", nil, 100.0), NewSeriesFloat64("s", nil, 101.0), IsEqualOptions{RelTol: 0.01}, true},
+		{NewSeriesFloat64("s", nil, 1.0, 2.0, 2.0), NewSeriesFloat64("s", nil, 2.0, 1.0, 2.0), IsEqualOptions{Unordered: true}, true},
+		{NewSeriesFloat64("s", nil, 1.0, 1.0, 2.0), NewSeriesFloat64("s", nil, 2.0, 1.0, 2.0), IsEqualOptions{Unordered: true}, false},
+		{NewSeriesGeneric("s", 0.0, nil, math.NaN()), NewSeriesGeneric("s", 0.0, nil, math.NaN()), IsEqualOptions{}, false},
+		{NewSeriesGeneric("s", 0.0, nil, math.NaN()), NewSeriesGeneric("s", 0.0, nil, math.NaN()), IsEqualOptions{NaNEqual: true}, true},
+		{NewSeriesTime("s", nil, t1), NewSeriesTime("s", nil, t1.Add(time.Second)), IsEqualOptions{}, false},
+		{NewSeriesTime("s", nil, t1), NewSeriesTime("s", nil, t1.Add(time.Second)), IsEqualOptions{TimeTolerance: time.Second}, true},
+		{NewSeriesString("s", nil, "a", nil), NewSeriesString("s", nil, nil, "a"), IsEqualOptions{Unordered: true}, true},
+		{NewSeriesFloat64("s", nil, 1.0, 1.09), NewSeriesFloat64("s", nil, 1.08, 1.0), IsEqualOptions{Unordered: true, AbsTol: 0.1}, true},
+		{NewSeriesFloat64("s", nil, 1.0, 1.3), NewSeriesFloat64("s", nil, 1.08, 1.0), IsEqualOptions{Unordered: true, AbsTol: 0.1}, false},
+		{NewSeriesInt64("s", nil, 1), NewSeriesFloat64("s", nil, 1.0), IsEqualOptions{AbsTol: 1}, false},
+	}
+
+	for i, tc := range tests {
+		eq, err := tc.s1.IsEqual(ctx, tc.s2, tc.opts)
+		if err != nil {
+			t.Fatalf("error encountered: %s\n", err)
+		}
+
+		if eq != tc.expected {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, eq)
+		}
+	}
+}
+
+func TestSeriesList(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesList("s", NewSeriesFloat64("", nil), nil, []float64{3, 1}, nil, []interface{}{1, nil}, []int64{})
+
+	if s.Type() != "list(float64)" {
+		t.Errorf("wrong type: expected: %v actual: %v", "list(float64)", s.Type())
+	}
+
+	expected := []interface{}{[]interface{}{3.0, 1.0}, nil, []interface{}{1.0, nil}, []interface{}{}}
+	for row, exp := range expected {
+		if !cmp.Equal(s.Value(row), exp) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", row, exp, s.Value(row))
+		}
+	}
+
+	if nc, _ := s.NilCount(); nc != 1 {
+		t.Errorf("wrong nil count: expected: %v actual: %v", 1, nc)
+	}
+
+	// Invalid element
+	func() {
+		defer func() {
+			if x := recover(); x == nil {
+				t.Errorf("wrong val: expected: %v actual: %v", "panic", x)
+			}
+		}()
+		s.Append([]string{"abc"})
+	}()
+
+	// Sort (lexicographic)
+	s.Sort(ctx)
+
+	sorted := NewSeriesList("s", NewSeriesFloat64("", nil), nil, nil, []float64{}, []interface{}{1.0, nil}, []float64{3, 1})
+	if eq, _ := s.IsEqual(ctx, sorted); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", sorted, s)
+	}
+
+	// Approximate equality
+	approx := NewSeriesList("s", NewSeriesFloat64("", nil), nil, nil, []float64{}, []interface{}{1.0 + 1e-12, nil}, []float64{3, 1})
+	if eq, _ := s.IsEqual(ctx, approx, IsEqualOptions{AbsTol: 1e-9}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", approx, s)
+	}
+
+	// Binary
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	sb := &SeriesList{}
+	if err := sb.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, _ := s.IsEqual(ctx, sb, IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", s, sb)
+	}
+
+	// JSON
+	data, err = s.MarshalJSON()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	sj := &SeriesList{}
+	if err := sj.UnmarshalJSON(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, _ := s.IsEqual(ctx, sj, IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v (%s)", s, sj, data)
+	}
+}
+
+func TestSeriesStruct(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesStruct("address", nil,
+		NewSeriesString("city", nil, "Sydney", "Perth"),
+		NewSeriesInt64("postcode", nil, 2000, nil),
+	)
+	s.Append(nil)
+	s.Append(map[string]interface{}{"city": "Adelaide"})
+
+	if s.Type() != "struct{city string, postcode int64}" {
+		t.Errorf("wrong type: expected: %v actual: %v", "struct{city string, postcode int64}", s.Type())
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"city": "Sydney", "postcode": int64(2000)},
+		map[string]interface{}{"city": "Perth", "postcode": nil},
+		nil,
+		map[string]interface{}{"city": "Adelaide", "postcode": nil},
+	}
+	for row, exp := range expected {
+		if !cmp.Equal(s.Value(row), exp) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", row, exp, s.Value(row))
+		}
+	}
+
+	if nc, _ := s.NilCount(); nc != 1 {
+		t.Errorf("wrong nil count: expected: %v actual: %v", 1, nc)
+	}
+
+	// Unknown field
+	func() {
+		defer func() {
+			if x := recover(); x == nil {
+				t.Errorf("wrong val: expected: %v actual: %v", "panic", x)
+			}
+		}()
+		s.Append(map[string]interface{}{"country": "AU"})
+	}()
+
+	// Sort
+	s.Sort(ctx)
+
+	sorted := NewSeriesStruct("address", nil,
+		NewSeriesString("city", nil, nil, "Adelaide", "Perth", "Sydney"),
+		NewSeriesInt64("postcode", nil, nil, nil, nil, 2000),
+	)
+	sorted.Update(0, nil)
+
+	if eq, _ := s.IsEqual(ctx, sorted); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", sorted, s)
+	}
+
+	// Binary
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	sb := &SeriesStruct{}
+	if err := sb.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, _ := s.IsEqual(ctx, sb, IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", s, sb)
+	}
+
+	// JSON
+	data, err = s.MarshalJSON()
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	sj := &SeriesStruct{}
+	if err := sj.UnmarshalJSON(data); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	if eq, _ := s.IsEqual(ctx, sj, IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v (%s)", s, sj, data)
+	}
+}
@@ -24,6 +24,12 @@ type SeriesTime struct {
 	// See: https://golang.org/pkg/time/#Parse
 	Layout string
 
+	// Location, when set, is the time zone of the Series. All values are converted to Location
+	// when they are inserted. Use TZConvert or TZLocalize to change the Location of an existing Series.
+	//
+	// See: https://golang.org/pkg/time/#LoadLocation
+	Location *time.Location
+
 	lock sync.RWMutex
 	name string
 
@@ -32,6 +38,7 @@ type SeriesTime struct {
 	// WARNING: Do not modify directly.
 	Values   []*time.Time
 	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
 }
 
 // NewSeriesTime creates a new series with the underlying type as time.Time.
@@ -105,7 +112,9 @@ func NewSeriesTime(name string, init *SeriesInit, vals ...interface{}) *SeriesTi
 
 // NewSeries creates a new initialized SeriesTime.
 func (s *SeriesTime) NewSeries(name string, init *SeriesInit) Series {
-	return NewSeriesTime(name, init)
+	ns := NewSeriesTime(name, init)
+	ns.Location = s.Location
+	return ns
 }
 
 // Name returns the series name.
@@ -177,6 +186,8 @@ func (s *SeriesTime) Prepend(val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
 
 	if cap(s.Values) > len(s.Values) {
@@ -221,17 +232,31 @@ func (s *SeriesTime) Insert(row int, val interface{}, opts ...Options) {
 }
 
 func (s *SeriesTime) insert(row int, val interface{}) {
+	s.own()
 
 	switch V := val.(type) {
 	case []time.Time:
 		var vals []*time.Time
 		for _, v := range V {
 			v := v
+			if s.Location != nil {
+				v = v.In(s.Location)
+			}
 			vals = append(vals, &v)
 		}
 		s.Values = append(s.Values[:row], append(vals, s.Values[row:]...)...)
 		return
 	case []*time.Time:
+		if s.Location != nil {
+			vals := make([]*time.Time, 0, len(V))
+			for _, v := range V {
+				if v != nil {
+					v = &[]time.Time{v.In(s.Location)}[0]
+				}
+				vals = append(vals, v)
+			}
+			V = vals
+		}
 		for _, v := range V {
 			if v == nil {
 				s.nilCount++
@@ -259,6 +284,8 @@ func (s *SeriesTime) Remove(row int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if s.Values[row] == nil {
 		s.nilCount--
 	}
@@ -274,6 +301,7 @@ func (s *SeriesTime) Reset(opts ...Options) {
 	}
 
 	s.Values = []*time.Time{}
+	s.shared = false
 	s.nilCount = 0
 }
 
@@ -286,6 +314,8 @@ func (s *SeriesTime) Update(row int, val interface{}, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	newVal := s.valToPointer(val)
 
 	if s.Values[row] == nil && newVal != nil {
@@ -352,6 +382,14 @@ func (s *SeriesTime) ValuesIterator(opts ...ValuesOptions) func() (*int, interfa
 }
 
 func (s *SeriesTime) valToPointer(v interface{}) *time.Time {
+	t := s.toPointer(v)
+	if t != nil && s.Location != nil {
+		*t = t.In(s.Location)
+	}
+	return t
+}
+
+func (s *SeriesTime) toPointer(v interface{}) *time.Time {
 	switch val := v.(type) {
 	case nil:
 		return nil
@@ -427,6 +465,8 @@ func (s *SeriesTime) Swap(row1, row2 int, opts ...Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	s.Values[row1], s.Values[row2] = s.Values[row2], s.Values[row1]
 }
 
@@ -487,6 +527,8 @@ func (s *SeriesTime) Sort(ctx context.Context, opts ...SortOptions) (completed b
 		defer s.Unlock()
 	}
 
+	s.own()
+
 	sortFunc := func(i, j int) (ret bool) {
 		if err := ctx.Err(); err != nil {
 			panic(err)
@@ -498,6 +540,14 @@ func (s *SeriesTime) Sort(ctx context.Context, opts ...SortOptions) (completed b
 			}
 		}()
 
+		if opts[0].Nils != NilsDefault {
+			iNil, jNil := s.Values[i] == nil, s.Values[j] == nil
+			if iNil || jNil {
+				// Pre-invert since ret is inverted above for Desc
+				return nilsLess(iNil, jNil, opts[0].Nils) != opts[0].Desc
+			}
+		}
+
 		if s.Values[i] == nil {
 			if s.Values[j] == nil {
 				// both are nil
@@ -537,6 +587,16 @@ func (s *SeriesTime) Unlock() {
 	s.lock.Unlock()
 }
 
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesTime) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesTime) RUnlock() {
+	s.lock.RUnlock()
+}
+
 // Copy will create a new copy of the series.
 // It is recommended that you lock the Series before attempting
 // to Copy.
@@ -545,6 +605,8 @@ func (s *SeriesTime) Copy(r ...Range) Series {
 	if len(s.Values) == 0 {
 		return &SeriesTime{
 			valFormatter: s.valFormatter,
+			Layout:       s.Layout,
+			Location:     s.Location,
 			name:         s.name,
 			Values:       []*time.Time{},
 			nilCount:     s.nilCount,
@@ -566,12 +628,70 @@ func (s *SeriesTime) Copy(r ...Range) Series {
 
 	return &SeriesTime{
 		valFormatter: s.valFormatter,
+		Layout:       s.Layout,
+		Location:     s.Location,
 		name:         s.name,
 		Values:       newSlice,
 		nilCount:     s.nilCount,
 	}
 }
 
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+//
+// NOTE: Modifying Values directly does not trigger a copy.
+func (s *SeriesTime) View(r Range) Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.Values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.Values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.Values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.Values) {
+		nilCount = 0
+		for _, v := range vals {
+			if v == nil {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesTime{
+		valFormatter: s.valFormatter,
+		Layout:       s.Layout,
+		Location:     s.Location,
+		name:         s.name,
+		Values:       vals,
+		nilCount:     nilCount,
+		shared:       true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesTime) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([]*time.Time, len(s.Values), cap(s.Values))
+	copy(vals, s.Values)
+	s.Values = vals
+	s.shared = false
+}
+
 // Table will produce the Series in a table.
 func (s *SeriesTime) Table(opts ...TableOptions) string {
 
@@ -865,6 +985,8 @@ func (s *SeriesTime) FillRand(src rand.Source, probNil float64, rander Rander, o
 
 	rng := rand.New(src)
 
+	s.own()
+
 	capacity := cap(s.Values)
 	length := len(s.Values)
 	s.nilCount = 0
@@ -918,6 +1040,11 @@ func (s *SeriesTime) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOpti
 		}
 	}
 
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
 	// Check values
 	for i, v := range s.Values {
 		if err := ctx.Err(); err != nil {
@@ -940,3 +1067,166 @@ func (s *SeriesTime) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOpti
 
 	return true, nil
 }
+
+type seriesTimeBinary struct {
+	Name      string
+	Values    []time.Time
+	Nils      []int
+	Formatter string
+	Layout    string
+	Location  string
+	Offset    *int // offset of Location (in seconds east of UTC)
+}
+
+// locationOffset returns the offset of loc (in seconds east of UTC) at the Unix epoch.
+// It is used to recreate locations that were created using time.FixedZone.
+func locationOffset(loc *time.Location) *int {
+	_, offset := time.Unix(0, 0).In(loc).Zone()
+	return &offset
+}
+
+// loadLocation returns the Location with the given name. If it can't be loaded (eg. it was created using
+// time.FixedZone) and offset is provided, a fixed zone with the given name and offset is returned.
+func loadLocation(name string, offset *int) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if offset == nil {
+			return nil, err
+		}
+		return time.FixedZone(name, *offset), nil
+	}
+	return loc, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+//
+// NOTE: The Location is stored by name, so it must be available (using time.LoadLocation) when the Series is decoded.
+// Otherwise, a fixed zone with the same name and offset is used.
+func (s *SeriesTime) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	out := seriesTimeBinary{
+		Name:      s.name,
+		Values:    make([]time.Time, len(s.Values)),
+		Formatter: ValueFormatterName(s.valFormatter),
+		Layout:    s.Layout,
+	}
+
+	if s.Location != nil {
+		out.Location = s.Location.String()
+		out.Offset = locationOffset(s.Location)
+	}
+
+	for idx, v := range s.Values {
+		if v == nil {
+			out.Nils = append(out.Nils, idx)
+		} else {
+			out.Values[idx] = *v
+		}
+	}
+
+	return gobEncode(out)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesTime) UnmarshalBinary(data []byte) error {
+	var in seriesTimeBinary
+	if err := gobDecode(data, &in); err != nil {
+		return err
+	}
+
+	loc, err := loadLocation(in.Location, in.Offset)
+	if err != nil {
+		return err
+	}
+
+	vals := make([]*time.Time, len(in.Values))
+	for idx := range in.Values {
+		if loc != nil {
+			in.Values[idx] = in.Values[idx].In(loc)
+		}
+		vals[idx] = &in.Values[idx]
+	}
+	for _, idx := range in.Nils {
+		if idx < 0 || idx >= len(vals) {
+			return fmt.Errorf("invalid nil row: %d", idx)
+		}
+		vals[idx] = nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.Values = vals
+	s.nilCount = len(in.Nils)
+	s.valFormatter = LookupValueFormatter(in.Formatter)
+	s.Layout = in.Layout
+	s.Location = loc
+	s.shared = false
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Nil values are encoded as null.
+// Times are encoded using RFC 3339 format. The Location is stored in the same way as MarshalBinary.
+func (s *SeriesTime) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	sj := seriesJSON{
+		Name:      s.name,
+		Type:      "time",
+		Formatter: ValueFormatterName(s.valFormatter),
+		Layout:    s.Layout,
+	}
+
+	if s.Location != nil {
+		sj.Location = s.Location.String()
+		sj.Offset = locationOffset(s.Location)
+	}
+
+	return marshalSeriesJSON(sj, s.Values)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesTime) UnmarshalJSON(data []byte) error {
+	vals := []*time.Time{}
+	sj, err := unmarshalSeriesJSON(data, "time", &vals)
+	if err != nil {
+		return err
+	}
+
+	loc, err := loadLocation(sj.Location, sj.Offset)
+	if err != nil {
+		return err
+	}
+
+	var nilCount int
+	for _, v := range vals {
+		if v == nil {
+			nilCount++
+		} else if loc != nil {
+			*v = v.In(loc)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = sj.Name
+	s.Values = vals
+	s.nilCount = nilCount
+	s.valFormatter = LookupValueFormatter(sj.Formatter)
+	s.Layout = sj.Layout
+	s.Location = loc
+	s.shared = false
+
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// mapInt64 creates a new SeriesInt64 by applying fn to each non-nil value.
+// Nil values remain nil.
+func (s *SeriesTime) mapInt64(ctx context.Context, fn func(time.Time) int64, opts []Options) (*SeriesInt64, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	si := NewSeriesInt64(s.name, &SeriesInit{Capacity: len(s.Values)})
+
+	for _, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			si.values = append(si.values, nil)
+			si.nilCount++
+		} else {
+			cv := fn(*rowVal)
+			si.values = append(si.values, &cv)
+		}
+	}
+
+	return si, nil
+}
+
+// Year returns a SeriesInt64 containing the year of each value. Nil values remain nil.
+func (s *SeriesTime) Year(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.Year())
+	}, opts)
+}
+
+// Quarter returns a SeriesInt64 containing the quarter of the year (1-4) of each value. Nil values remain nil.
+func (s *SeriesTime) Quarter(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.Month()-1)/3 + 1
+	}, opts)
+}
+
+// Month returns a SeriesInt64 containing the month of the year (1-12) of each value. Nil values remain nil.
+func (s *SeriesTime) Month(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.Month())
+	}, opts)
+}
+
+// ISOWeek returns a SeriesInt64 containing the ISO 8601 week number (1-53) of each value. Nil values remain nil.
+//
+// See: https://golang.org/pkg/time/#Time.ISOWeek
+func (s *SeriesTime) ISOWeek(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		_, week := t.ISOWeek()
+		return int64(week)
+	}, opts)
+}
+
+// DayOfYear returns a SeriesInt64 containing the day of the year (1-366) of each value. Nil values remain nil.
+func (s *SeriesTime) DayOfYear(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.YearDay())
+	}, opts)
+}
+
+// Day returns a SeriesInt64 containing the day of the month (1-31) of each value. Nil values remain nil.
+func (s *SeriesTime) Day(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.Day())
+	}, opts)
+}
+
+// Weekday returns a SeriesInt64 containing the day of the week of each value,
+// where Sunday is 0 and Saturday is 6. Nil values remain nil.
+//
+// See: https://golang.org/pkg/time/#Weekday
+func (s *SeriesTime) Weekday(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.Weekday())
+	}, opts)
+}
+
+// Hour returns a SeriesInt64 containing the hour (0-23) of each value. Nil values remain nil.
+func (s *SeriesTime) Hour(ctx context.Context, opts ...Options) (*SeriesInt64, error) {
+	return s.mapInt64(ctx, func(t time.Time) int64 {
+		return int64(t.Hour())
+	}, opts)
+}
+
+// Format returns a SeriesString containing each value formatted according to layout. Nil values remain nil.
+//
+// See: https://golang.org/pkg/time/#Time.Format
+func (s *SeriesTime) Format(ctx context.Context, layout string, opts ...Options) (*SeriesString, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	ss := NewSeriesString(s.name, &SeriesInit{Capacity: len(s.Values)})
+
+	for _, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			ss.values = append(ss.values, nil)
+			ss.nilCount++
+		} else {
+			cv := rowVal.Format(layout)
+			ss.values = append(ss.values, &cv)
+		}
+	}
+
+	return ss, nil
+}
+
+// mapTime creates a new SeriesTime with a Location of loc by applying fn to each non-nil value.
+// Nil values remain nil.
+func (s *SeriesTime) mapTime(ctx context.Context, loc *time.Location, fn func(time.Time) time.Time, opts []Options) (*SeriesTime, error) {
+	if loc == nil {
+		panic("loc is required")
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	st := NewSeriesTime(s.name, &SeriesInit{Capacity: len(s.Values)})
+	st.valFormatter = s.valFormatter
+	st.Layout = s.Layout
+	st.Location = loc
+
+	for _, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			st.Values = append(st.Values, nil)
+			st.nilCount++
+		} else {
+			cv := fn(*rowVal).In(loc)
+			st.Values = append(st.Values, &cv)
+		}
+	}
+
+	return st, nil
+}
+
+// TZConvert returns a new SeriesTime with its Location set to loc. Each value represents
+// the same instant in time, but is presented in loc. Nil values remain nil.
+//
+// Example:
+//
+//  loc, _ := time.LoadLocation("Australia/Sydney")
+//  s := dataframe.NewSeriesTime("time", nil, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+//
+//  syd, _ := s.TZConvert(ctx, loc)
+//  // Output: time: [ 2020-01-01 11:00:00 +1100 AEDT ]
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.Series.dt.tz_convert.html
+func (s *SeriesTime) TZConvert(ctx context.Context, loc *time.Location, opts ...Options) (*SeriesTime, error) {
+	return s.mapTime(ctx, loc, func(t time.Time) time.Time {
+		return t
+	}, opts)
+}
+
+// TZLocalize returns a new SeriesTime with its Location set to loc. Each value retains its wall clock
+// (year, month, day, hour, minute, second and nanosecond), but is reinterpreted as being in loc.
+// This is useful for data that was imported without time zone information. Nil values remain nil.
+//
+// Wall clocks that don't exist in loc (due to daylight saving time transitions) are normalized
+// as described by time.Date.
+//
+// Example:
+//
+//  loc, _ := time.LoadLocation("Australia/Sydney")
+//  s := dataframe.NewSeriesTime("time", nil, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+//
+//  syd, _ := s.TZLocalize(ctx, loc)
+//  // Output: time: [ 2020-01-01 00:00:00 +1100 AEDT ]
+//
+// See: https://golang.org/pkg/time/#Date
+func (s *SeriesTime) TZLocalize(ctx context.Context, loc *time.Location, opts ...Options) (*SeriesTime, error) {
+	return s.mapTime(ctx, loc, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	}, opts)
+}
+
+// Sub returns a SeriesDuration containing the duration between each value and the corresponding
+// value of s2 (i.e. s - s2). If either value is nil, the result is nil.
+func (s *SeriesTime) Sub(ctx context.Context, s2 *SeriesTime, opts ...Options) (*SeriesDuration, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		if s2 != s {
+			s2.lock.RLock()
+			defer s2.lock.RUnlock()
+		}
+	}
+
+	if len(s.Values) != len(s2.Values) {
+		return nil, errors.New("different number of rows in series")
+	}
+
+	sd := NewSeriesDuration(s.name, &SeriesInit{Capacity: len(s.Values)})
+
+	for row, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil || s2.Values[row] == nil {
+			sd.values = append(sd.values, nil)
+			sd.nilCount++
+		} else {
+			cv := rowVal.Sub(*s2.Values[row])
+			sd.values = append(sd.values, &cv)
+		}
+	}
+
+	return sd, nil
+}
+
+// Add returns a new SeriesTime where each value has the corresponding value of d added to it.
+// If either value is nil, the result is nil.
+func (s *SeriesTime) Add(ctx context.Context, d *SeriesDuration, opts ...Options) (*SeriesTime, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		d.lock.RLock()
+		defer d.lock.RUnlock()
+	}
+
+	if len(s.Values) != len(d.values) {
+		return nil, errors.New("different number of rows in series")
+	}
+
+	st := NewSeriesTime(s.name, &SeriesInit{Capacity: len(s.Values)})
+	st.valFormatter = s.valFormatter
+	st.Layout = s.Layout
+	st.Location = s.Location
+
+	for row, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil || d.values[row] == nil {
+			st.Values = append(st.Values, nil)
+			st.nilCount++
+		} else {
+			cv := rowVal.Add(*d.values[row])
+			st.Values = append(st.Values, &cv)
+		}
+	}
+
+	return st, nil
+}
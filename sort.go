@@ -4,6 +4,8 @@ package dataframe
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"sort"
 )
 
@@ -13,6 +15,33 @@ type IsEqualFunc func(a, b interface{}) bool
 // IsLessThanFunc returns true if a < b
 type IsLessThanFunc func(a, b interface{}) bool
 
+// NilPosition determines where nil values are placed when sorting.
+type NilPosition int
+
+const (
+	// NilsDefault treats a nil value as being less than all other values.
+	// This means nil values are placed first in ascending order and last in descending order.
+	NilsDefault NilPosition = 0
+
+	// NilsFirst places nil values at the beginning irrespective of the sort direction.
+	NilsFirst NilPosition = 1
+
+	// NilsLast places nil values at the end irrespective of the sort direction.
+	NilsLast NilPosition = 2
+)
+
+// nilsLess reports whether i must be placed before j when at least one of them is nil.
+func nilsLess(iNil, jNil bool, nils NilPosition) bool {
+	if iNil && jNil {
+		return false
+	}
+
+	if nils == NilsLast {
+		return jNil
+	}
+	return iNil
+}
+
 // SortKey is the key to sort a Dataframe
 type SortKey struct {
 
@@ -22,6 +51,14 @@ type SortKey struct {
 	// Desc can be set to sort in descending order.
 	Desc bool
 
+	// Nils determines where nil values are placed. When set to NilsDefault,
+	// the Nils value of SortOptions is used.
+	Nils NilPosition
+
+	// IsLessThanFunc can be set to override the Series' IsLessThanFunc for this key.
+	// a and b can be nil unless Nils (or SortOptions.Nils) is set.
+	IsLessThanFunc IsLessThanFunc
+
 	seriesIndex int
 }
 
@@ -29,6 +66,7 @@ type sorter struct {
 	keys []SortKey
 	df   *DataFrame
 	ctx  context.Context
+	nils NilPosition
 }
 
 func (s *sorter) Len() int {
@@ -36,6 +74,15 @@ func (s *sorter) Len() int {
 }
 
 func (s *sorter) Less(i, j int) bool {
+	return s.less(i, j)
+}
+
+func (s *sorter) Swap(i, j int) {
+	s.df.Swap(i, j, DontLock)
+}
+
+// less reports whether row i must be placed before row j.
+func (s *sorter) less(i, j int) bool {
 
 	if err := s.ctx.Err(); err != nil {
 		panic(err)
@@ -47,6 +94,28 @@ func (s *sorter) Less(i, j int) bool {
 		left := series.Value(i)
 		right := series.Value(j)
 
+		nils := key.Nils
+		if nils == NilsDefault {
+			nils = s.nils
+		}
+
+		if nils != NilsDefault && (left == nil || right == nil) {
+			if left == nil && right == nil {
+				continue
+			}
+			return nilsLess(left == nil, right == nil, nils)
+		}
+
+		if key.IsLessThanFunc != nil {
+			if key.IsLessThanFunc(left, right) {
+				return !key.Desc
+			}
+			if key.IsLessThanFunc(right, left) {
+				return key.Desc
+			}
+			continue
+		}
+
 		// Check if left and right are equal
 		if series.IsEqualFunc(left, right) {
 			continue
@@ -62,8 +131,22 @@ func (s *sorter) Less(i, j int) bool {
 	return false
 }
 
-func (s *sorter) Swap(i, j int) {
-	s.df.Swap(i, j, DontLock)
+// argSorter sorts a permutation of the row positions instead of the rows themselves.
+type argSorter struct {
+	*sorter
+	perm []int
+}
+
+func (s *argSorter) Len() int {
+	return len(s.perm)
+}
+
+func (s *argSorter) Less(i, j int) bool {
+	return s.less(s.perm[i], s.perm[j])
+}
+
+func (s *argSorter) Swap(i, j int) {
+	s.perm[i], s.perm[j] = s.perm[j], s.perm[i]
 }
 
 // SortOptions is used to configure the sort algorithm for a Dataframe or Series
@@ -78,69 +161,260 @@ type SortOptions struct {
 	// Only use it with a Series.
 	Desc bool
 
+	// Nils determines where nil values are placed. When applied to a Dataframe, it is used
+	// for all keys that don't set their own Nils value.
+	Nils NilPosition
+
+	// Parallel can be set to sort a Dataframe using all available cores. The order of the rows
+	// is determined once using a parallel merge sort and then applied to each Series concurrently.
+	// Parallel sorting is always stable.
+	//
+	// NOTE: This option only applies to DataFrames.
+	Parallel bool
+
+	// MemoryBudget sets the maximum number of bytes used to determine the order of the rows of a Dataframe.
+	// When the budget is exceeded, sorted runs are spilled to temporary files and then merged in as many
+	// passes as required to keep the merge buffers within the budget (external sort). A value of 0 means no limit.
+	//
+	// NOTE: The Dataframe itself is always kept in memory. Sort reorders its rows in place without
+	// loading the order of the rows into memory. The order of the rows returned by ArgSort is always kept in memory.
+	//
+	// NOTE: This option only applies to DataFrames.
+	MemoryBudget int
+
+	// TempDir is the directory used to store the runs of an external sort.
+	// If TempDir is blank, the default directory for temporary files is used.
+	TempDir string
+
 	// DontLock can be set to true if the Series should not be locked.
 	DontLock bool
 }
 
+// sortKeys returns a copy of keys with the position of each Series resolved.
+func (df *DataFrame) sortKeys(keys []SortKey) ([]SortKey, error) {
+	out := make([]SortKey, 0, len(keys))
+
+	for _, key := range keys {
+		switch k := key.Key.(type) {
+		case string:
+			col, err := df.NameToColumn(k, dontLock)
+			if err != nil {
+				return nil, err
+			}
+			key.seriesIndex = col
+		case int:
+			if k < 0 || k >= len(df.Series) {
+				return nil, fmt.Errorf("sort key out of range: %d", k)
+			}
+			key.seriesIndex = k
+		default:
+			return nil, fmt.Errorf("sort key must be an int or string: %T", key.Key)
+		}
+		out = append(out, key)
+	}
+
+	return out, nil
+}
+
 // Sort is used to sort the Dataframe according to different keys.
 // It will return true if sorting was completed or false when the context is canceled.
+// False is also returned if an external sort fails (eg. the temporary files can't be written).
+// Use SortErr to obtain the reason sorting was not completed.
 func (df *DataFrame) Sort(ctx context.Context, keys []SortKey, opts ...SortOptions) (completed bool) {
 	if len(keys) == 0 {
 		return true
 	}
 
+	if len(opts) == 0 {
+		opts = append(opts, SortOptions{})
+	}
+
+	if !opts[0].DontLock {
+		// Default
+		df.lock.Lock()
+		defer df.lock.Unlock()
+	}
+
+	sks, err := df.sortKeys(keys)
+	if err != nil {
+		panic(err)
+	}
+
+	return df.sortRows(ctx, sks, opts[0]) == nil
+}
+
+// SortErr is the same as Sort except that an error is returned when sorting is not completed.
+// The error is the context's error when it is canceled. Unlike Sort, invalid keys return an error
+// instead of panicking.
+func (df *DataFrame) SortErr(ctx context.Context, keys []SortKey, opts ...SortOptions) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if len(opts) == 0 {
+		opts = append(opts, SortOptions{})
+	}
+
+	if !opts[0].DontLock {
+		// Default
+		df.lock.Lock()
+		defer df.lock.Unlock()
+	}
+
+	sks, err := df.sortKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	return df.sortRows(ctx, sks, opts[0])
+}
+
+// sortRows sorts the rows of the Dataframe. The Dataframe must already be locked.
+func (df *DataFrame) sortRows(ctx context.Context, keys []SortKey, opts SortOptions) (rErr error) {
+
 	defer func() {
 		if x := recover(); x != nil {
 			if x == context.Canceled || x == context.DeadlineExceeded {
-				completed = false
+				rErr = x.(error)
 			} else {
 				panic(x)
 			}
 		}
 	}()
 
-	if len(opts) == 0 || !opts[0].DontLock {
+	s := &sorter{
+		keys: keys,
+		df:   df,
+		ctx:  ctx,
+		nils: opts.Nils,
+	}
+
+	if s.external(opts) {
+		name, err := s.externalSort(opts)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(name)
+
+		// The order of the rows is not loaded into memory
+		return df.applyRun(ctx, name)
+	}
+
+	if opts.Parallel {
+		perm, err := s.argSort(opts)
+		if err != nil {
+			return err
+		}
+		df.applyPermutation(perm)
+		return nil
+	}
+
+	if !opts.Stable {
 		// Default
-		df.lock.Lock()
-		defer df.lock.Unlock()
+		sort.Sort(s)
+	} else {
+		sort.Stable(s)
 	}
 
-	// Clear seriesIndex from keys
+	return nil
+}
+
+// ArgSort returns the order of rows that would sort the Dataframe according to different keys.
+// The Dataframe itself is not modified. The i-th element of the returned slice is the
+// position of the row that would be placed at position i.
+//
+// Example:
+//
+//  perm, _ := df.ArgSort(ctx, []dataframe.SortKey{{Key: "sales", Desc: true}})
+//  top := df.Row(perm[0], false)
+//
+func (df *DataFrame) ArgSort(ctx context.Context, keys []SortKey, opts ...SortOptions) (_ []int, rErr error) {
+
 	defer func() {
-		for i := range keys {
-			key := &keys[i]
-			key.seriesIndex = 0
+		if x := recover(); x != nil {
+			if x == context.Canceled || x == context.DeadlineExceeded {
+				rErr = x.(error)
+			} else {
+				panic(x)
+			}
 		}
 	}()
 
-	// Convert keys to index
-	for i := range keys {
-		key := &keys[i]
+	if len(opts) == 0 {
+		opts = append(opts, SortOptions{})
+	}
 
-		name, ok := key.Key.(string)
-		if ok {
-			col, err := df.NameToColumn(name, dontLock)
-			if err != nil {
-				panic(err)
-			}
-			key.seriesIndex = col
-		} else {
-			key.seriesIndex = key.Key.(int)
-		}
+	if !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	if len(keys) == 0 {
+		return identityPermutation(0, df.n), nil
+	}
+
+	sks, err := df.sortKeys(keys)
+	if err != nil {
+		return nil, err
 	}
 
 	s := &sorter{
-		keys: keys,
+		keys: sks,
 		df:   df,
 		ctx:  ctx,
+		nils: opts[0].Nils,
 	}
 
-	if len(opts) == 0 || !opts[0].Stable {
-		// Default
-		sort.Sort(s)
+	return s.argSort(opts[0])
+}
+
+// external reports whether the memory budget is too small to sort the rows of the Dataframe at once.
+func (s *sorter) external(opts SortOptions) bool {
+	return opts.MemoryBudget > 0 && s.df.n*bytesPerSortedRow > opts.MemoryBudget
+}
+
+// argSort returns the order of rows that would sort the Dataframe.
+func (s *sorter) argSort(opts SortOptions) ([]int, error) {
+	n := s.df.n
+
+	if s.external(opts) {
+		name, err := s.externalSort(opts)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(name)
+
+		perm := make([]int, 0, n)
+		err = readRun(name, func(row int) {
+			perm = append(perm, row)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return perm, nil
+	}
+
+	perm := identityPermutation(0, n)
+
+	if opts.Parallel {
+		return s.parallelSort(perm)
+	}
+
+	as := &argSorter{sorter: s, perm: perm}
+	if !opts.Stable {
+		sort.Sort(as)
 	} else {
-		sort.Stable(s)
+		sort.Stable(as)
 	}
 
-	return true
+	return perm, nil
+}
+
+// identityPermutation returns the rows from start (inclusive) to end (exclusive) in their original order.
+func identityPermutation(start, end int) []int {
+	perm := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		perm = append(perm, i)
+	}
+	return perm
 }
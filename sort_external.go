@@ -0,0 +1,367 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// bytesPerSortedRow is the approximate memory required per row to determine
+// the order of the rows (the permutation and the merge buffer).
+const bytesPerSortedRow = 16
+
+// runBufferSize is the size of the buffer used to read or write a run.
+const runBufferSize = 4096
+
+// run is a sorted subset of rows that was spilled to a temporary file.
+type run struct {
+	name string
+	f    *os.File
+	r    *bufio.Reader
+
+	head int // current row
+}
+
+func (r *run) open() error {
+	f, err := os.Open(r.name)
+	if err != nil {
+		return err
+	}
+	r.f = f
+	r.r = bufio.NewReaderSize(f, runBufferSize)
+	return nil
+}
+
+func (r *run) close() {
+	if r.f != nil {
+		r.f.Close()
+		r.f = nil
+	}
+}
+
+func (r *run) next() (bool, error) {
+	var b [8]byte
+	_, err := io.ReadFull(r.r, b[:])
+	if err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	r.head = int(binary.LittleEndian.Uint64(b[:]))
+	return true, nil
+}
+
+// runWriter writes rows to a new run.
+type runWriter struct {
+	f *os.File
+	w *bufio.Writer
+	b [8]byte
+}
+
+func newRunWriter(dir string) (*runWriter, error) {
+	f, err := ioutil.TempFile(dir, "dataframe-sort-")
+	if err != nil {
+		return nil, err
+	}
+	return &runWriter{f: f, w: bufio.NewWriterSize(f, runBufferSize)}, nil
+}
+
+func (w *runWriter) write(row int) error {
+	binary.LittleEndian.PutUint64(w.b[:], uint64(row))
+	_, err := w.w.Write(w.b[:])
+	return err
+}
+
+// close flushes and closes the run. The run is removed if an error occurred.
+func (w *runWriter) close(err error) (string, error) {
+	if err == nil {
+		err = w.w.Flush()
+	}
+	if cErr := w.f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		os.Remove(w.f.Name())
+		return "", err
+	}
+	return w.f.Name(), nil
+}
+
+// runHeap is used to perform a k-way merge of the runs.
+type runHeap struct {
+	s    *sorter
+	runs []*run
+	idx  []int // position of each run in the original order of runs
+}
+
+func (h *runHeap) Len() int { return len(h.runs) }
+
+func (h *runHeap) Less(i, j int) bool {
+	if h.s.less(h.runs[i].head, h.runs[j].head) {
+		return true
+	}
+	if h.s.less(h.runs[j].head, h.runs[i].head) {
+		return false
+	}
+	// Runs contain consecutive rows so the earlier run goes first to maintain stability
+	return h.idx[i] < h.idx[j]
+}
+
+func (h *runHeap) Swap(i, j int) {
+	h.runs[i], h.runs[j] = h.runs[j], h.runs[i]
+	h.idx[i], h.idx[j] = h.idx[j], h.idx[i]
+}
+
+func (h *runHeap) Push(x interface{}) {
+	panic("not supported")
+}
+
+func (h *runHeap) Pop() interface{} {
+	n := len(h.runs) - 1
+	r := h.runs[n]
+	h.runs = h.runs[:n]
+	h.idx = h.idx[:n]
+	return r
+}
+
+// externalSort performs a stable sort of the rows when the memory budget is too small
+// to sort all the rows at once. The rows are divided into runs that each fit within the budget.
+// Each run is sorted and then written to a temporary file. The runs are then merged in multiple passes,
+// so that the buffers of the runs being merged also fit within the budget.
+//
+// The name of the temporary file containing the sorted order of the rows is returned.
+// The caller is responsible for removing it.
+func (s *sorter) externalSort(opts SortOptions) (_ string, rErr error) {
+	n := s.df.n
+
+	runSize := opts.MemoryBudget / bytesPerSortedRow
+	if runSize < 1 {
+		runSize = 1
+	}
+
+	// Each run being merged and the merged run require a buffer
+	fanIn := opts.MemoryBudget/runBufferSize - 1
+	if fanIn < 2 {
+		fanIn = 2
+	}
+
+	names := []string{}
+	defer func() {
+		if rErr != nil {
+			for _, name := range names {
+				os.Remove(name)
+			}
+		}
+	}()
+
+	// Create the runs
+	for start := 0; start < n; start = start + runSize {
+		if err := s.ctx.Err(); err != nil {
+			return "", err
+		}
+
+		end := start + runSize
+		if end > n {
+			end = n
+		}
+
+		perm := identityPermutation(start, end)
+
+		if opts.Parallel {
+			var err error
+			perm, err = s.parallelSort(perm)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			err := protect(func() {
+				sort.Stable(&argSorter{sorter: s, perm: perm})
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+
+		w, err := newRunWriter(opts.TempDir)
+		if err != nil {
+			return "", err
+		}
+
+		for _, row := range perm {
+			if err = w.write(row); err != nil {
+				break
+			}
+		}
+
+		name, err := w.close(err)
+		if err != nil {
+			return "", err
+		}
+		names = append(names, name)
+	}
+
+	// Merge consecutive runs (to maintain stability) until one remains
+	for len(names) > 1 {
+		merged := []string{}
+		for start := 0; start < len(names); start = start + fanIn {
+			end := start + fanIn
+			if end > len(names) {
+				end = len(names)
+			}
+
+			name, err := s.mergeRuns(names[start:end], opts.TempDir)
+			if err != nil {
+				for _, name := range merged {
+					os.Remove(name)
+				}
+				return "", err
+			}
+			merged = append(merged, name)
+
+			for _, name := range names[start:end] {
+				os.Remove(name)
+			}
+		}
+		names = merged
+	}
+
+	return names[0], nil
+}
+
+// mergeRuns merges the runs into a new run and returns its name.
+func (s *sorter) mergeRuns(names []string, dir string) (string, error) {
+
+	runs := make([]*run, 0, len(names))
+	defer func() {
+		for _, r := range runs {
+			r.close()
+		}
+	}()
+
+	h := &runHeap{s: s}
+	for i, name := range names {
+		r := &run{name: name}
+		if err := r.open(); err != nil {
+			return "", err
+		}
+		runs = append(runs, r)
+
+		ok, err := r.next()
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			h.runs = append(h.runs, r)
+			h.idx = append(h.idx, i)
+		}
+	}
+
+	w, err := newRunWriter(dir)
+	if err != nil {
+		return "", err
+	}
+
+	// less panics when the context is canceled
+	pErr := protect(func() {
+		heap.Init(h)
+		for h.Len() > 0 {
+			r := h.runs[0]
+			if err = w.write(r.head); err != nil {
+				return
+			}
+
+			var ok bool
+			if ok, err = r.next(); err != nil {
+				return
+			}
+			if ok {
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	})
+	if err == nil {
+		err = pErr
+	}
+
+	return w.close(err)
+}
+
+// readRun calls fn for each row of a run (in order).
+func readRun(name string, fn func(row int)) error {
+	r := &run{name: name}
+	if err := r.open(); err != nil {
+		return err
+	}
+	defer r.close()
+
+	for {
+		ok, err := r.next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		fn(r.head)
+	}
+}
+
+// applyRun reorders the rows of the Dataframe according to the order stored in a run.
+// The order is read from the run as required, so it is not loaded into memory. The rows are
+// swapped in place by following the cycles of the permutation, so the values are not copied.
+// The rows are only partially reordered if an error occurs.
+func (df *DataFrame) applyRun(ctx context.Context, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var b [8]byte
+	source := func(row int) (int, error) {
+		if _, err := f.ReadAt(b[:], int64(row)*int64(len(b))); err != nil {
+			return 0, err
+		}
+		return int(binary.LittleEndian.Uint64(b[:])), nil
+	}
+
+	// visited records the rows that have been placed (1 bit per row)
+	visited := make([]uint64, (df.n+63)/64)
+
+	for start := 0; start < df.n; start++ {
+		if visited[start/64]&(1<<uint(start%64)) != 0 {
+			continue
+		}
+
+		row := start
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			visited[row/64] |= 1 << uint(row%64)
+
+			next, err := source(row)
+			if err != nil {
+				return err
+			}
+			if next == start {
+				break
+			}
+			df.Swap(row, next, dontLock)
+			row = next
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"fmt"
+	"golang.org/x/sync/errgroup"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// minParallelSortRows is the smallest number of rows worth sorting on a separate goroutine.
+const minParallelSortRows = 2048
+
+// protect converts a panic raised by fn into an error. It is used to recover from
+// the panics raised by sorter.less (eg. when the context is canceled) on other goroutines.
+func protect(fn func()) (rErr error) {
+	defer func() {
+		if x := recover(); x != nil {
+			if err, ok := x.(error); ok {
+				rErr = err
+			} else {
+				rErr = fmt.Errorf("%v", x)
+			}
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+// parallelSort performs a stable merge sort of perm. Chunks of perm are sorted
+// concurrently and then merged pairwise (also concurrently) until one chunk remains.
+// The returned slice may not share the same backing array as perm.
+func (s *sorter) parallelSort(perm []int) ([]int, error) {
+	n := len(perm)
+
+	nChunks := runtime.NumCPU()
+	if max := n / minParallelSortRows; nChunks > max {
+		nChunks = max
+	}
+
+	if nChunks < 2 {
+		err := protect(func() {
+			sort.Stable(&argSorter{sorter: s, perm: perm})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return perm, nil
+	}
+
+	// Sort each chunk
+	bounds := []int{0}
+	for i := 1; i <= nChunks; i++ {
+		bounds = append(bounds, i*n/nChunks)
+	}
+
+	var g errgroup.Group
+	for i := 0; i < nChunks; i++ {
+		chunk := perm[bounds[i]:bounds[i+1]]
+		g.Go(func() error {
+			return protect(func() {
+				sort.Stable(&argSorter{sorter: s, perm: chunk})
+			})
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Merge neighbouring chunks
+	buf := make([]int, n)
+
+	for len(bounds) > 2 {
+		newBounds := []int{0}
+
+		var g errgroup.Group
+		for i := 0; i < len(bounds)-1; i = i + 2 {
+			lo := bounds[i]
+
+			if i+2 >= len(bounds) {
+				// Odd chunk out
+				hi := bounds[i+1]
+				copy(buf[lo:hi], perm[lo:hi])
+				newBounds = append(newBounds, hi)
+				continue
+			}
+
+			mid, hi := bounds[i+1], bounds[i+2]
+			g.Go(func() error {
+				return protect(func() {
+					s.merge(perm[lo:mid], perm[mid:hi], buf[lo:hi])
+				})
+			})
+			newBounds = append(newBounds, hi)
+		}
+
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+
+		perm, buf = buf, perm
+		bounds = newBounds
+	}
+
+	return perm, nil
+}
+
+// merge merges the sorted slices a and b into dst. When rows are equal,
+// rows from a are placed first so that the merge is stable.
+func (s *sorter) merge(a, b, dst []int) {
+	var i, j, k int
+
+	for i < len(a) && j < len(b) {
+		if s.less(b[j], a[i]) {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+
+	k = k + copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// applyPermutation reorders the rows of the Dataframe such that row i becomes the row
+// previously found at position perm[i]. Each Series is reordered concurrently.
+// The operation can't be canceled because a partially applied permutation would leave
+// the rows of the Dataframe inconsistent.
+func (df *DataFrame) applyPermutation(perm []int) {
+	var wg sync.WaitGroup
+
+	for i := range df.Series {
+		s := df.Series[i]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			s.Lock()
+			defer s.Unlock()
+
+			// Follow each cycle of the permutation
+			visited := make([]bool, len(perm))
+			for start := range perm {
+				if visited[start] {
+					continue
+				}
+				visited[start] = true
+
+				row := start
+				for {
+					next := perm[row]
+					if next == start {
+						break
+					}
+					s.Swap(row, next, dontLock)
+					visited[next] = true
+					row = next
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,456 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// structField describes how a struct field maps to a Series.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	tagged    bool // name was provided by a struct tag
+}
+
+// structFields returns the exported fields of t (including fields of embedded structs).
+// The name of each field can be customized using a struct tag. eg. `dataframe:"name,omitempty"`.
+// A tag of "-" will ignore the field.
+//
+// When several fields have the same name, the same rules as encoding/json are used: the shallowest field wins.
+// If several fields are equally shallow, a tagged field wins. Otherwise, all of them are ignored.
+func structFields(t reflect.Type) []structField {
+	fields := collectStructFields(t, nil, map[reflect.Type]bool{})
+
+	byName := map[string][]int{}
+	for i, f := range fields {
+		byName[f.name] = append(byName[f.name], i)
+	}
+
+	out := make([]structField, 0, len(fields))
+	for i, f := range fields {
+		if dominantField(fields, byName[f.name]) == i {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// collectStructFields returns all the candidate fields of t. index is the index of t within the outer struct.
+// visiting contains the embedded structs that are being collected (to prevent infinite recursion).
+func collectStructFields(t reflect.Type, index []int, visiting map[reflect.Type]bool) []structField {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields := []structField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := sf.Tag.Get("dataframe")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx != -1 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+
+		fIndex := append(append([]int{}, index...), i)
+
+		// Flatten embedded structs
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			isPtr := ft.Kind() == reflect.Ptr
+			if isPtr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && ft != timeType {
+				// Pointers to unexported structs can't be allocated
+				if (isPtr && sf.PkgPath != "") || visiting[ft] {
+					continue
+				}
+				fields = append(fields, collectStructFields(ft, fIndex, visiting)...)
+				continue
+			}
+		}
+
+		if sf.PkgPath != "" {
+			// Unexported
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = sf.Name
+		}
+
+		fields = append(fields, structField{
+			name:      name,
+			index:     fIndex,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			tagged:    tagged,
+		})
+	}
+
+	return fields
+}
+
+// dominantField returns the position (in fields) of the field that takes precedence amongst the fields
+// at positions idxs, which have the same name. -1 is returned if the fields are ambiguous.
+func dominantField(fields []structField, idxs []int) int {
+	depth := len(fields[idxs[0]].index)
+	for _, i := range idxs[1:] {
+		if d := len(fields[i].index); d < depth {
+			depth = d
+		}
+	}
+
+	shallowest := []int{}
+	tagged := []int{}
+	for _, i := range idxs {
+		if len(fields[i].index) != depth {
+			continue
+		}
+		shallowest = append(shallowest, i)
+		if fields[i].tagged {
+			tagged = append(tagged, i)
+		}
+	}
+
+	if len(shallowest) == 1 {
+		return shallowest[0]
+	}
+	if len(tagged) == 1 {
+		return tagged[0]
+	}
+	return -1
+}
+
+// fieldByIndex returns the field of v, allocating embedded struct pointers when alloc is true.
+// ok is false if a nil embedded struct pointer was encountered.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (_ reflect.Value, ok bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// newSeriesForType creates a Series that is suitable for holding values of type t.
+func newSeriesForType(name string, t reflect.Type, init *SeriesInit) Series {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return NewSeriesTime(name, init)
+	case durationType:
+		return NewSeriesDuration(name, init)
+	}
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return NewSeriesFloat64(name, init)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewSeriesInt64(name, init)
+	case reflect.Bool:
+		s := NewSeriesInt64(name, init)
+		s.SetValueToStringFormatter(BoolValueFormatter)
+		return s
+	case reflect.String:
+		return NewSeriesString(name, init)
+	case reflect.Complex64, reflect.Complex128, reflect.Struct:
+		return NewSeriesGeneric(name, reflect.Zero(t).Interface(), init)
+	default:
+		return NewSeriesMixed(name, init)
+	}
+}
+
+// seriesValue converts a struct field's value into a value that can be inserted into a Series.
+func seriesValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType, durationType:
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Bool:
+		return int64(B(v.Bool()))
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Map, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	return v.Interface()
+}
+
+// FromStructs creates a DataFrame from a slice of structs (or pointers to structs).
+// Each exported field becomes a Series. The name of the Series can be customized using a struct tag.
+// A tag of "-" will ignore the field. The omitempty option will store zero values as nil.
+// Nil pointers are stored as nil. Fields of embedded structs are treated as fields of the outer struct
+// (a field of the outer struct takes precedence over an embedded field with the same name).
+//
+// Fields are stored in the following Series:
+//
+//  float32, float64: SeriesFloat64
+//  int, int8 ... uint64: SeriesInt64
+//  bool: SeriesInt64 (with BoolValueFormatter)
+//  string: SeriesString
+//  time.Time: SeriesTime
+//  time.Duration: SeriesDuration
+//  complex64, complex128, struct: SeriesGeneric
+//  other: SeriesMixed
+//
+// Example:
+//
+//  type Order struct {
+//     ID       int64     `dataframe:"id"`
+//     Customer string    `dataframe:"customer"`
+//     Total    float64   `dataframe:"total"`
+//     Shipped  time.Time `dataframe:"shipped,omitempty"`
+//     internal string
+//  }
+//
+//  df, err := dataframe.FromStructs(ctx, []Order{...})
+//
+func FromStructs(ctx context.Context, slice interface{}) (*DataFrame, error) {
+
+	sv := reflect.ValueOf(slice)
+	if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+		return nil, errors.New("slice must be a slice of structs")
+	}
+
+	et := sv.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return nil, errors.New("slice must be a slice of structs")
+	}
+
+	fields := structFields(et)
+	if len(fields) == 0 {
+		return nil, errors.New("struct contains no exported fields")
+	}
+
+	init := &SeriesInit{Capacity: sv.Len()}
+
+	seriess := make([]Series, 0, len(fields))
+	for _, f := range fields {
+		seriess = append(seriess, newSeriesForType(f.name, et.FieldByIndex(f.index).Type, init))
+	}
+
+	for row := 0; row < sv.Len(); row++ {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rv := sv.Index(row)
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				for _, s := range seriess {
+					s.Append(nil, dontLock)
+				}
+				continue
+			}
+			rv = rv.Elem()
+		}
+
+		for i, f := range fields {
+			fv, ok := fieldByIndex(rv, f.index, false)
+			if !ok || (f.omitEmpty && fv.IsZero()) {
+				seriess[i].Append(nil, dontLock)
+				continue
+			}
+			seriess[i].Append(seriesValue(fv), dontLock)
+		}
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+// setField sets the value of a struct field. val is a value returned by a Series.
+func setField(fv reflect.Value, val interface{}) error {
+	if val == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		nv := reflect.New(fv.Type().Elem())
+		if err := setField(nv.Elem(), val); err != nil {
+			return err
+		}
+		fv.Set(nv)
+		return nil
+	}
+
+	v := reflect.ValueOf(val)
+
+	switch fv.Kind() {
+	case reflect.Bool:
+		if i, ok := val.(int64); ok {
+			fv.SetBool(i != 0)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// Numeric conversions (including time.Duration)
+		switch v.Kind() {
+		case reflect.Int64, reflect.Float64:
+			fv.Set(v.Convert(fv.Type()))
+			return nil
+		}
+	}
+
+	if v.Type().AssignableTo(fv.Type()) {
+		fv.Set(v)
+		return nil
+	}
+
+	// Named types (eg. type Status string)
+	if v.Kind() == fv.Kind() && v.Type().ConvertibleTo(fv.Type()) {
+		fv.Set(v.Convert(fv.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("can't assign %T to %s", val, fv.Type())
+}
+
+// rowInto decodes a row into rv (a struct value).
+func (df *DataFrame) rowInto(row int, rv reflect.Value, fields []structField) error {
+	for _, f := range fields {
+		idx, err := df.NameToColumn(f.name, dontLock)
+		if err != nil {
+			// Series does not exist
+			continue
+		}
+
+		fv, _ := fieldByIndex(rv, f.index, true)
+		if err := setField(fv, df.Series[idx].Value(row, dontLock)); err != nil {
+			return &RowError{Row: row, Err: &SeriesError{Series: f.name, Err: err}}
+		}
+	}
+	return nil
+}
+
+// RowInto decodes the values of a particular row into dst, which must be a pointer to a struct.
+// Series are matched to fields using the same rules as FromStructs. Fields without a matching Series
+// are set to their zero value and Series without a matching field are ignored.
+// Nil values are stored as the zero value (or a nil pointer).
+func (df *DataFrame) RowInto(row int, dst interface{}, opts ...Options) error {
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("dst must be a pointer to a struct")
+	}
+	rv = rv.Elem()
+
+	if row < 0 || row >= df.n {
+		return errors.New("row out of range")
+	}
+
+	rv.Set(reflect.Zero(rv.Type()))
+	return df.rowInto(row, rv, structFields(rv.Type()))
+}
+
+// ToStructs decodes all rows into dst, which must be a pointer to a slice of structs (or pointers to structs).
+// The slice is replaced with a slice containing one element per row.
+// See RowInto for how Series are matched to fields.
+//
+// Example:
+//
+//  orders := []Order{}
+//  err := df.ToStructs(ctx, &orders)
+//
+func (df *DataFrame) ToStructs(ctx context.Context, dst interface{}, opts ...Options) error {
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("dst must be a pointer to a slice of structs")
+	}
+	sv := rv.Elem()
+
+	et := sv.Type().Elem()
+	isPtr := et.Kind() == reflect.Ptr
+	if isPtr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return errors.New("dst must be a pointer to a slice of structs")
+	}
+
+	fields := structFields(et)
+
+	out := reflect.MakeSlice(sv.Type(), df.n, df.n)
+	for row := 0; row < df.n; row++ {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ev := out.Index(row)
+		if isPtr {
+			ev.Set(reflect.New(et))
+			ev = ev.Elem()
+		}
+
+		if err := df.rowInto(row, ev, fields); err != nil {
+			return err
+		}
+	}
+
+	sv.Set(out)
+	return nil
+}
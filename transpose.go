@@ -0,0 +1,160 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// TransposeOptions modifies the behavior of the Transpose function.
+type TransposeOptions struct {
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Transpose flips the rows and columns of a DataFrame.
+//
+// headerCol can be an int (position of series) or string (name of series). The values of that Series are used
+// as the names of the new Series. If headerCol is nil, the row numbers are used instead. The first Series of the
+// returned DataFrame is a SeriesString containing the names of the original Series (excluding headerCol).
+//
+// All the new Series share the same type, which is determined by the types of the original Series:
+// SeriesFloat64 (for a mixture of SeriesFloat64 and SeriesInt64), SeriesInt64, SeriesString or SeriesTime
+// when the original Series are all of that type. Otherwise SeriesMixed is used.
+//
+// Example:
+//
+//  // +-----+------+----+----+
+//  // |     | NAME | Q1 | Q2 |
+//  // +-----+------+----+----+
+//  // | 0:  |  a   | 1  | 2  |
+//  // | 1:  |  b   | 3  | 4  |
+//  // +-----+------+----+----+
+//
+//  tdf, _ := dataframe.Transpose(ctx, df, "name")
+//
+//  // +-----+------+---+---+
+//  // |     | NAME | A | B |
+//  // +-----+------+---+---+
+//  // | 0:  |  q1  | 1 | 3 |
+//  // | 1:  |  q2  | 2 | 4 |
+//  // +-----+------+---+---+
+//
+func Transpose(ctx context.Context, df *DataFrame, headerCol interface{}, opts ...TransposeOptions) (*DataFrame, error) {
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	headerIdx := -1
+	indexName := ""
+
+	switch h := headerCol.(type) {
+	case nil:
+	case int:
+		if h < 0 || h >= len(df.Series) {
+			return nil, errors.New("headerCol out of range")
+		}
+		headerIdx = h
+	case string:
+		col, err := df.NameToColumn(h, dontLock)
+		if err != nil {
+			return nil, errors.New(err.Error() + ": " + h)
+		}
+		headerIdx = col
+	default:
+		panic("headerCol must be an int or string")
+	}
+
+	// Determine the names of the new Series
+	names := make([]string, 0, df.n)
+	if headerIdx == -1 {
+		for row := 0; row < df.n; row++ {
+			names = append(names, strconv.Itoa(row))
+		}
+	} else {
+		hs := df.Series[headerIdx]
+		indexName = hs.Name()
+		for row := 0; row < df.n; row++ {
+			names = append(names, hs.ValueString(row))
+		}
+	}
+
+	// Determine the Series that become rows
+	sources := []Series{}
+	for idx, s := range df.Series {
+		if idx != headerIdx {
+			sources = append(sources, s)
+		}
+	}
+
+	// Create the new Series
+	index := NewSeriesString(indexName, &SeriesInit{Capacity: len(sources)})
+	for _, s := range sources {
+		index.Append(s.Name(), dontLock)
+	}
+
+	newType := transposedType(sources)
+
+	unique := map[string]struct{}{indexName: {}}
+	seriess := []Series{index}
+
+	for row, name := range names {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, exists := unique[name]; exists {
+			return nil, errors.New("names of series must be unique: " + name)
+		}
+		unique[name] = struct{}{}
+
+		ns := newType.NewSeries(name, &SeriesInit{Capacity: len(sources)})
+		for _, s := range sources {
+			ns.Append(s.Value(row), dontLock)
+		}
+		seriess = append(seriess, ns)
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+// transposedType returns a Series that can hold the values of all the sources.
+func transposedType(sources []Series) NewSerieser {
+
+	var float64s, int64s, strings, times int
+
+	for _, s := range sources {
+		switch s.(type) {
+		case *SeriesFloat64:
+			float64s++
+		case *SeriesInt64:
+			int64s++
+		case *SeriesString:
+			strings++
+		case *SeriesTime:
+			times++
+		}
+	}
+
+	n := len(sources)
+
+	switch {
+	case n == 0:
+		return &SeriesMixed{}
+	case int64s == n:
+		return &SeriesInt64{}
+	case float64s+int64s == n:
+		return &SeriesFloat64{}
+	case strings == n:
+		return &SeriesString{}
+	case times == n:
+		return &SeriesTime{}
+	default:
+		return &SeriesMixed{}
+	}
+}
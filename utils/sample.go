@@ -0,0 +1,242 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// SampleOptions modifies the behavior of Sample.
+type SampleOptions struct {
+
+	// N is the number of rows to sample. Either N or Frac must be set.
+	N int
+
+	// Frac is the fraction of rows to sample. The number of rows is rounded to the nearest integer.
+	Frac float64
+
+	// Replace can be set to true if rows can be sampled more than once.
+	// N (or Frac) can then exceed the number of rows.
+	Replace bool
+
+	// Weights is used to set the probability of each row being sampled. It must contain a non-negative weight for every row.
+	// The weights don't need to sum to 1. When nil (default), every row is equally likely.
+	Weights []float64
+
+	// Stratify is used to perform stratified sampling. The rows are grouped by the values of the Series with the given name.
+	// N (or Frac) is then applied to each group separately.
+	Stratify string
+
+	// Src is the source of randomness. When nil, a source seeded with the current time is used.
+	//
+	// Example:
+	//
+	//  src := rand.NewSource(uint64(time.Now().UTC().UnixNano()))
+	//
+	Src rand.Source
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Sample returns a new DataFrame containing a random sample of the rows of df.
+// Rows are returned in the order they were sampled. The DataFrame is not shuffled.
+//
+// Example:
+//
+//  // Sample 10% of the rows from each country
+//  sample, err := utils.Sample(ctx, df, utils.SampleOptions{Frac: 0.1, Stratify: "country"})
+//
+func Sample(ctx context.Context, df *dataframe.DataFrame, opts SampleOptions) (*dataframe.DataFrame, error) {
+
+	if opts.N < 0 || opts.Frac < 0 {
+		return nil, errors.New("N and Frac must not be negative")
+	}
+
+	if (opts.N == 0) == (opts.Frac == 0) {
+		return nil, errors.New("either N or Frac must be set")
+	}
+
+	if !opts.DontLock {
+		df.RLock()
+		defer df.RUnlock()
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+
+	if opts.Weights != nil {
+		if len(opts.Weights) != nRows {
+			return nil, fmt.Errorf("expected %d weights but got %d", nRows, len(opts.Weights))
+		}
+		for _, w := range opts.Weights {
+			if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+				return nil, fmt.Errorf("invalid weight: %v", w)
+			}
+		}
+	}
+
+	rng := newRand(opts.Src)
+
+	// Group rows
+	groups, err := stratify(ctx, df, opts.Stratify)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sample rows
+	selected := []int{}
+
+	for _, rows := range groups {
+		k := opts.N
+		if opts.Frac != 0 {
+			k = int(math.Round(opts.Frac * float64(len(rows))))
+		}
+
+		sampled, err := sampleRows(ctx, rng, rows, k, opts.Weights, opts.Replace)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, sampled...)
+	}
+
+	return selectRows(ctx, df, selected)
+}
+
+// selectRows returns a new DataFrame containing the given rows of df. df is not locked.
+// The Series are created using Copy so that their settings (eg. value formatter) are retained.
+func selectRows(ctx context.Context, df *dataframe.DataFrame, rows []int) (*dataframe.DataFrame, error) {
+
+	seriess := []dataframe.Series{}
+	for _, s := range df.Series {
+		var ns dataframe.Series
+		if s.NRows(dataframe.DontLock) == 0 {
+			ns = s.Copy()
+		} else {
+			ns = s.Copy(dataframe.RangeFinite(0, 0))
+			ns.Reset(dataframe.DontLock)
+		}
+		seriess = append(seriess, ns)
+	}
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for i, s := range df.Series {
+			seriess[i].Append(s.Value(row, dataframe.DontLock), dataframe.DontLock)
+		}
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// sampleRows selects k rows. weights is indexed by row.
+func sampleRows(ctx context.Context, rng *rand.Rand, rows []int, k int, weights []float64, replace bool) ([]int, error) {
+
+	if k == 0 {
+		return []int{}, nil
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("can't sample from 0 rows")
+	}
+
+	if !replace && k > len(rows) {
+		return nil, fmt.Errorf("can't sample %d rows from %d rows without replacement", k, len(rows))
+	}
+
+	out := make([]int, 0, k)
+
+	if weights == nil {
+		if replace {
+			for i := 0; i < k; i++ {
+				out = append(out, rows[rng.Intn(len(rows))])
+			}
+			return out, nil
+		}
+
+		// Partial Fisher-Yates shuffle (without modifying rows)
+		swapped := map[int]int{}
+		get := func(i int) int {
+			if v, exists := swapped[i]; exists {
+				return v
+			}
+			return rows[i]
+		}
+
+		for i := 0; i < k; i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			j := i + rng.Intn(len(rows)-i)
+			vi, vj := get(i), get(j)
+			swapped[j] = vi
+			out = append(out, vj)
+		}
+		return out, nil
+	}
+
+	if replace {
+		// Inverse transform sampling
+		cum := make([]float64, 0, len(rows))
+		var total float64
+		for _, row := range rows {
+			total = total + weights[row]
+			cum = append(cum, total)
+		}
+
+		if total == 0 {
+			return nil, errors.New("weights must not all be zero")
+		}
+
+		for i := 0; i < k; i++ {
+			u := rng.Float64() * total
+			idx := sort.Search(len(cum), func(j int) bool { return cum[j] > u })
+			if idx == len(cum) {
+				idx = len(cum) - 1
+			}
+			out = append(out, rows[idx])
+		}
+		return out, nil
+	}
+
+	// Weighted sampling without replacement (Efraimidis & Spirakis)
+	type keyed struct {
+		row int
+		key float64
+	}
+
+	ks := make([]keyed, 0, len(rows))
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		w := weights[row]
+		if w == 0 {
+			continue
+		}
+		ks = append(ks, keyed{row, math.Log(rng.Float64()) / w})
+	}
+
+	if k > len(ks) {
+		return nil, fmt.Errorf("can't sample %d rows from %d rows with non-zero weight", k, len(ks))
+	}
+
+	sort.Slice(ks, func(i, j int) bool { return ks[i].key > ks[j].key })
+
+	for _, v := range ks[:k] {
+		out = append(out, v.row)
+	}
+	return out, nil
+}
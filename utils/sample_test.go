@@ -0,0 +1,158 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+var ctx = context.Background()
+
+// sampleDataFrame returns a DataFrame with 10 rows. The id of each row is its row number.
+// Rows 0-5 belong to group "a" and rows 6-9 belong to group "b".
+func sampleDataFrame() *dataframe.DataFrame {
+	return dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("id", nil, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+		dataframe.NewSeriesString("group", nil, "a", "a", "a", "a", "a", "a", "b", "b", "b", "b"),
+	)
+}
+
+// ids returns the ids of the rows of df.
+func ids(df *dataframe.DataFrame) []int64 {
+	out := []int64{}
+	for row := 0; row < df.NRows(); row++ {
+		out = append(out, df.Series[0].Value(row).(int64))
+	}
+	return out
+}
+
+func TestSample(t *testing.T) {
+
+	df := sampleDataFrame()
+
+	// Seeded reproducibility
+	for _, opts := range []SampleOptions{
+		{N: 5},
+		{N: 15, Replace: true},
+		{Frac: 0.5, Weights: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{Frac: 0.5, Stratify: "group"},
+	} {
+		opts.Src = rand.NewSource(42)
+		s1, err := Sample(ctx, df, opts)
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+		}
+
+		opts.Src = rand.NewSource(42)
+		s2, err := Sample(ctx, df, opts)
+		if err != nil {
+			t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+		}
+
+		if eq, _ := s1.IsEqual(ctx, s2); !eq {
+			t.Errorf("wrong val: expected: %v actual: %v", ids(s1), ids(s2))
+		}
+	}
+
+	// Without replacement
+	sample, err := Sample(ctx, df, SampleOptions{Frac: 1, Src: rand.NewSource(1)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	seen := map[int64]bool{}
+	for _, id := range ids(sample) {
+		if seen[id] {
+			t.Errorf("wrong val: row %d sampled more than once", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != 10 {
+		t.Errorf("wrong val: expected: %v actual: %v", 10, len(seen))
+	}
+
+	_, err = Sample(ctx, df, SampleOptions{N: 11, Src: rand.NewSource(1)})
+	if err == nil {
+		t.Errorf("wrong err: expected: %v actual: %v", "error", err)
+	}
+
+	// With replacement
+	sample, err = Sample(ctx, df, SampleOptions{N: 50, Replace: true, Src: rand.NewSource(1)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	if sample.NRows() != 50 {
+		t.Errorf("wrong val: expected: %v actual: %v", 50, sample.NRows())
+	}
+
+	for _, id := range ids(sample) {
+		if id < 0 || id > 9 {
+			t.Errorf("wrong val: unexpected row %d", id)
+		}
+	}
+
+	// Weighted (only rows with a non-zero weight are sampled)
+	weights := []float64{0, 0, 1, 0, 0, 0, 0, 3, 0, 0}
+
+	sample, err = Sample(ctx, df, SampleOptions{N: 2, Weights: weights, Src: rand.NewSource(1)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	actual := ids(sample)
+	if len(actual) != 2 || actual[0] == actual[1] || (actual[0] != 2 && actual[0] != 7) || (actual[1] != 2 && actual[1] != 7) {
+		t.Errorf("wrong val: expected: %v actual: %v", "rows 2 and 7", actual)
+	}
+
+	sample, err = Sample(ctx, df, SampleOptions{N: 20, Replace: true, Weights: weights, Src: rand.NewSource(1)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	for _, id := range ids(sample) {
+		if id != 2 && id != 7 {
+			t.Errorf("wrong val: row %d has zero weight", id)
+		}
+	}
+
+	_, err = Sample(ctx, df, SampleOptions{N: 3, Weights: weights, Src: rand.NewSource(1)})
+	if err == nil {
+		t.Errorf("wrong err: expected: %v actual: %v", "error", err)
+	}
+
+	// Stratified (half of each group)
+	sample, err = Sample(ctx, df, SampleOptions{Frac: 0.5, Stratify: "group", Src: rand.NewSource(1)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	counts := map[string]int{}
+	for row := 0; row < sample.NRows(); row++ {
+		counts[sample.Series[1].Value(row).(string)]++
+	}
+
+	if counts["a"] != 3 || counts["b"] != 2 {
+		t.Errorf("wrong val: expected: %v actual: %v", map[string]int{"a": 3, "b": 2}, counts)
+	}
+
+	// Invalid options
+	for i, opts := range []SampleOptions{
+		{},
+		{N: 2, Frac: 0.5},
+		{N: -1},
+		{N: 2, Weights: []float64{1, 2}},
+		{N: 2, Weights: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, -1}},
+		{N: 2, Stratify: "unknown"},
+	} {
+		_, err := Sample(ctx, df, opts)
+		if err == nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, "error", err)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/rand"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// Split contains the rows of a training set and a test set.
+// The rows are sorted in ascending order.
+type Split struct {
+	Train []int
+	Test  []int
+}
+
+// TrainRanges returns the rows of the training set as Ranges.
+func (s Split) TrainRanges() []dataframe.Range {
+	return dataframe.IntsToRanges(s.Train)
+}
+
+// TestRanges returns the rows of the test set as Ranges.
+func (s Split) TestRanges() []dataframe.Range {
+	return dataframe.IntsToRanges(s.Test)
+}
+
+// DataFrames returns the training set and test set as DataFrames.
+// When the rows of a set are contiguous, a View of df is returned (zero-copy).
+// Otherwise the rows are copied.
+//
+// See: dataframe.DataFrame.View
+func (s Split) DataFrames(ctx context.Context, df *dataframe.DataFrame, opts ...dataframe.Options) (train, test *dataframe.DataFrame, _ error) {
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.RLock()
+		defer df.RUnlock()
+	}
+
+	var err error
+
+	train, err = splitDataFrame(ctx, df, s.Train)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	test, err = splitDataFrame(ctx, df, s.Test)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return train, test, nil
+}
+
+// splitDataFrame returns the given rows of df. df must already be locked.
+func splitDataFrame(ctx context.Context, df *dataframe.DataFrame, rows []int) (*dataframe.DataFrame, error) {
+	if rs := dataframe.IntsToRanges(rows); len(rs) == 1 {
+		return df.View(rs[0], dataframe.DontLock), nil
+	}
+
+	return selectRows(ctx, df, rows)
+}
+
+// TrainTestSplitOptions modifies the behavior of TrainTestSplit.
+type TrainTestSplitOptions struct {
+
+	// Src is the source of randomness used to shuffle the rows. When nil, a source seeded with the current time is used.
+	Src rand.Source
+
+	// DontShuffle can be set to true if the rows should not be shuffled. The last rows form the test set.
+	DontShuffle bool
+
+	// Stratify is used to ensure that the proportion of each value of the Series with the given name
+	// is the same in the training set and test set.
+	Stratify string
+
+	// TimeOrdered is used to split the rows using the values of the SeriesTime with the given name.
+	// The most recent rows form the test set so that no row in the training set is more recent than a row
+	// in the test set. Rows with the same time are always placed in the same set. The rows are not shuffled.
+	TimeOrdered string
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// TrainTestSplit splits the rows of df into a training set and a test set.
+// testRatio is the fraction of rows that form the test set. The number of rows is rounded to the nearest integer.
+//
+// Example:
+//
+//  split, _ := utils.TrainTestSplit(ctx, df, 0.2, utils.TrainTestSplitOptions{Stratify: "label"})
+//  train, test, _ := split.DataFrames(ctx, df)
+//
+func TrainTestSplit(ctx context.Context, df *dataframe.DataFrame, testRatio float64, opts ...TrainTestSplitOptions) (Split, error) {
+
+	var options TrainTestSplitOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if testRatio < 0 || testRatio > 1 {
+		return Split{}, errors.New("testRatio must be between 0 and 1")
+	}
+
+	if options.Stratify != "" && options.TimeOrdered != "" {
+		return Split{}, errors.New("Stratify and TimeOrdered can't both be set")
+	}
+
+	if !options.DontLock {
+		df.RLock()
+		defer df.RUnlock()
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+	split := Split{Train: []int{}, Test: []int{}}
+
+	if options.TimeOrdered != "" {
+		rows, times, err := timeOrder(ctx, df, options.TimeOrdered)
+		if err != nil {
+			return Split{}, err
+		}
+
+		nTest := int(math.Round(testRatio * float64(nRows)))
+		boundary := nRows - nTest
+
+		// Rows with the same time must be in the test set
+		for boundary > 0 && boundary < nRows && times[boundary-1].Equal(times[boundary]) {
+			boundary--
+		}
+
+		split.Train = append(split.Train, rows[:boundary]...)
+		split.Test = append(split.Test, rows[boundary:]...)
+	} else {
+		groups, err := stratify(ctx, df, options.Stratify)
+		if err != nil {
+			return Split{}, err
+		}
+
+		var rng *rand.Rand
+		if !options.DontShuffle {
+			rng = newRand(options.Src)
+		}
+
+		for _, rows := range groups {
+			if rng != nil {
+				rng.Shuffle(len(rows), func(i, j int) { rows[i], rows[j] = rows[j], rows[i] })
+			}
+
+			nTest := int(math.Round(testRatio * float64(len(rows))))
+			split.Train = append(split.Train, rows[:len(rows)-nTest]...)
+			split.Test = append(split.Test, rows[len(rows)-nTest:]...)
+		}
+	}
+
+	sort.Ints(split.Train)
+	sort.Ints(split.Test)
+
+	return split, nil
+}
+
+// KFoldOptions modifies the behavior of KFold.
+type KFoldOptions struct {
+
+	// Shuffle can be set to true if the rows should be shuffled before they are split into folds.
+	Shuffle bool
+
+	// Src is the source of randomness used to shuffle the rows. When nil, a source seeded with the current time is used.
+	Src rand.Source
+
+	// Stratify is used to ensure that the proportion of each value of the Series with the given name
+	// is approximately the same in each fold.
+	Stratify string
+
+	// TimeOrdered is used to split the rows using the values of the SeriesTime with the given name.
+	// The rows are ordered by time and split into k+1 consecutive blocks. The i-th Split uses the first i blocks
+	// as the training set and the next block as the test set, so that no row in the training set is more
+	// recent than a row in the test set. Rows with the same time are always placed in the same block.
+	TimeOrdered string
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// KFold splits the rows of df into k folds for cross-validation. Each fold is used once as the test set while
+// the remaining folds form the training set. When the rows are not shuffled, each test set is contiguous.
+//
+// Example:
+//
+//  folds, _ := utils.KFold(ctx, df, 5, utils.KFoldOptions{Shuffle: true})
+//  for _, fold := range folds {
+//     train, test, _ := fold.DataFrames(ctx, df)
+//     ...
+//  }
+//
+func KFold(ctx context.Context, df *dataframe.DataFrame, k int, opts ...KFoldOptions) ([]Split, error) {
+
+	var options KFoldOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if k < 2 {
+		return nil, errors.New("k must be at least 2")
+	}
+
+	if options.Stratify != "" && options.TimeOrdered != "" {
+		return nil, errors.New("Stratify and TimeOrdered can't both be set")
+	}
+
+	if !options.DontLock {
+		df.RLock()
+		defer df.RUnlock()
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+
+	if options.TimeOrdered != "" {
+		if k+1 > nRows {
+			return nil, fmt.Errorf("can't split %d rows into %d blocks", nRows, k+1)
+		}
+
+		rows, times, err := timeOrder(ctx, df, options.TimeOrdered)
+		if err != nil {
+			return nil, err
+		}
+
+		// Determine the end of each block
+		ends := make([]int, 0, k+1)
+
+		var boundary int
+		for i, size := range foldSizes(nRows, k+1) {
+			boundary = boundary + size
+
+			// Rows with the same time must be in the later block
+			end := boundary
+			for end > 0 && end < nRows && times[end-1].Equal(times[end]) {
+				end--
+			}
+
+			if end == 0 || (i > 0 && end <= ends[i-1]) {
+				return nil, fmt.Errorf("can't split %d rows into %d blocks without separating rows with the same time", nRows, k+1)
+			}
+			ends = append(ends, end)
+		}
+
+		splits := make([]Split, 0, k)
+		for i := 1; i <= k; i++ {
+			split := Split{
+				Train: append([]int{}, rows[:ends[i-1]]...),
+				Test:  append([]int{}, rows[ends[i-1]:ends[i]]...),
+			}
+			sort.Ints(split.Train)
+			sort.Ints(split.Test)
+			splits = append(splits, split)
+		}
+		return splits, nil
+	}
+
+	if k > nRows {
+		return nil, fmt.Errorf("can't split %d rows into %d folds", nRows, k)
+	}
+
+	groups, err := stratify(ctx, df, options.Stratify)
+	if err != nil {
+		return nil, err
+	}
+
+	var rng *rand.Rand
+	if options.Shuffle {
+		rng = newRand(options.Src)
+	}
+
+	// Assign each row to a fold
+	fold := make([]int, nRows)
+
+	if options.Stratify == "" {
+		rows := groups[0]
+		if rng != nil {
+			rng.Shuffle(len(rows), func(i, j int) { rows[i], rows[j] = rows[j], rows[i] })
+		}
+
+		var start int
+		for f, size := range foldSizes(nRows, k) {
+			for _, row := range rows[start : start+size] {
+				fold[row] = f
+			}
+			start = start + size
+		}
+	} else {
+		// Deal the rows of each group into the folds
+		var f int
+		for _, rows := range groups {
+			if rng != nil {
+				rng.Shuffle(len(rows), func(i, j int) { rows[i], rows[j] = rows[j], rows[i] })
+			}
+			for _, row := range rows {
+				fold[row] = f
+				f = (f + 1) % k
+			}
+		}
+	}
+
+	splits := make([]Split, k)
+	for i := range splits {
+		splits[i] = Split{Train: []int{}, Test: []int{}}
+	}
+
+	for row, f := range fold {
+		for i := range splits {
+			if i == f {
+				splits[i].Test = append(splits[i].Test, row)
+			} else {
+				splits[i].Train = append(splits[i].Train, row)
+			}
+		}
+	}
+
+	return splits, nil
+}
+
+// foldSizes returns the sizes of k folds containing n rows. The first n % k folds contain an extra row.
+func foldSizes(n, k int) []int {
+	sizes := make([]int, k)
+	for i := range sizes {
+		sizes[i] = n / k
+		if i < n%k {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
+
+// stratify groups the rows of df by the values of the Series with the given name.
+// When name is blank, a single group containing all rows is returned.
+func stratify(ctx context.Context, df *dataframe.DataFrame, name string) ([][]int, error) {
+
+	nRows := df.NRows(dataframe.DontLock)
+
+	if name == "" {
+		rows := make([]int, nRows)
+		for i := range rows {
+			rows[i] = i
+		}
+		return [][]int{rows}, nil
+	}
+
+	col, err := df.NameToColumn(name, dataframe.DontLock)
+	if err != nil {
+		return nil, err
+	}
+	s := df.Series[col]
+
+	groups := [][]int{}
+	idx := map[string]int{}
+
+	for row := 0; row < nRows; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		val := s.Value(row, dataframe.DontLock)
+		if t, ok := val.(time.Time); ok {
+			val = t.UTC() // same instant => same group
+		}
+
+		key := fmt.Sprintf("%T:%v", val, val)
+		g, exists := idx[key]
+		if !exists {
+			g = len(groups)
+			idx[key] = g
+			groups = append(groups, []int{})
+		}
+		groups[g] = append(groups[g], row)
+	}
+
+	return groups, nil
+}
+
+// timeOrder returns the rows of df ordered by the values of the SeriesTime with the given name.
+func timeOrder(ctx context.Context, df *dataframe.DataFrame, name string) ([]int, []time.Time, error) {
+
+	col, err := df.NameToColumn(name, dataframe.DontLock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	st, ok := df.Series[col].(*dataframe.SeriesTime)
+	if !ok {
+		return nil, nil, &dataframe.SeriesError{Series: name, Err: errors.New("series must be a SeriesTime")}
+	}
+
+	nRows := len(st.Values)
+	rows := make([]int, 0, nRows)
+	for row, t := range st.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		if t == nil {
+			return nil, nil, &dataframe.SeriesError{Series: name, Err: &dataframe.RowError{Row: row, Err: errors.New("nil value")}}
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool { return st.Values[rows[i]].Before(*st.Values[rows[j]]) })
+
+	times := make([]time.Time, 0, nRows)
+	for _, row := range rows {
+		times = append(times, *st.Values[row])
+	}
+
+	return rows, times, nil
+}
+
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		src = rand.NewSource(uint64(time.Now().UTC().UnixNano()))
+	}
+	return rand.New(src)
+}
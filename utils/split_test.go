@@ -0,0 +1,263 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/rand"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// timeDataFrame returns a DataFrame with 7 rows. Rows 0 and 4 have the same time.
+// Ordered by time, the rows are: 1, 3, 0, 4, 6, 5, 2.
+func timeDataFrame() *dataframe.DataFrame {
+	base := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	t := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+
+	return dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("id", nil, 0, 1, 2, 3, 4, 5, 6),
+		dataframe.NewSeriesTime("time", nil, t(2), t(0), t(5), t(1), t(2), t(4), t(3)),
+	)
+}
+
+func TestTrainTestSplit(t *testing.T) {
+
+	df := sampleDataFrame()
+
+	// Not shuffled
+	split, err := TrainTestSplit(ctx, df, 0.3, TrainTestSplitOptions{DontShuffle: true})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expected := Split{Train: []int{0, 1, 2, 3, 4, 5, 6}, Test: []int{7, 8, 9}}
+	if !cmp.Equal(split, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, split)
+	}
+
+	// Seeded reproducibility
+	s1, _ := TrainTestSplit(ctx, df, 0.3, TrainTestSplitOptions{Src: rand.NewSource(7)})
+	s2, _ := TrainTestSplit(ctx, df, 0.3, TrainTestSplitOptions{Src: rand.NewSource(7)})
+	if !cmp.Equal(s1, s2) {
+		t.Errorf("wrong val: expected: %v actual: %v", s1, s2)
+	}
+
+	if len(s1.Train) != 7 || len(s1.Test) != 3 {
+		t.Errorf("wrong val: expected: %v actual: %v", "7 train and 3 test rows", s1)
+	}
+
+	// Stratified
+	split, err = TrainTestSplit(ctx, df, 0.5, TrainTestSplitOptions{Stratify: "group", Src: rand.NewSource(7)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	var testA, testB int
+	for _, row := range split.Test {
+		if row < 6 {
+			testA++
+		} else {
+			testB++
+		}
+	}
+	if testA != 3 || testB != 2 {
+		t.Errorf("wrong val: expected: %v actual: %v", "3 rows from a and 2 rows from b", split.Test)
+	}
+
+	// Stratified by times representing the same instant in different locations
+	loc, _ := time.LoadLocation("Australia/Melbourne")
+	t1 := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tdf := dataframe.NewDataFrame(dataframe.NewSeriesTime("time", nil, t1, t1.In(loc), t1.Add(time.Hour), t1.Add(time.Hour).In(loc)))
+
+	split, err = TrainTestSplit(ctx, tdf, 0.5, TrainTestSplitOptions{Stratify: "time", Src: rand.NewSource(7)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	if len(split.Test) != 2 || split.Test[0] > 1 || split.Test[1] < 2 {
+		t.Errorf("wrong val: expected: %v actual: %v", "1 row from each time", split.Test)
+	}
+
+	// Time ordered (rows with the same time are placed in the test set)
+	split, err = TrainTestSplit(ctx, timeDataFrame(), 0.5, TrainTestSplitOptions{TimeOrdered: "time"})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expected = Split{Train: []int{1, 3}, Test: []int{0, 2, 4, 5, 6}}
+	if !cmp.Equal(split, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, split)
+	}
+
+	// Invalid options
+	for i, tc := range []struct {
+		ratio float64
+		opts  TrainTestSplitOptions
+	}{
+		{-0.1, TrainTestSplitOptions{}},
+		{1.1, TrainTestSplitOptions{}},
+		{0.5, TrainTestSplitOptions{Stratify: "group", TimeOrdered: "group"}},
+		{0.5, TrainTestSplitOptions{TimeOrdered: "group"}},
+	} {
+		_, err := TrainTestSplit(ctx, df, tc.ratio, tc.opts)
+		if err == nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, "error", err)
+		}
+	}
+}
+
+func TestKFold(t *testing.T) {
+
+	df := sampleDataFrame()
+
+	// Not shuffled
+	folds, err := KFold(ctx, df, 3)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expected := []Split{
+		{Train: []int{4, 5, 6, 7, 8, 9}, Test: []int{0, 1, 2, 3}},
+		{Train: []int{0, 1, 2, 3, 7, 8, 9}, Test: []int{4, 5, 6}},
+		{Train: []int{0, 1, 2, 3, 4, 5, 6}, Test: []int{7, 8, 9}},
+	}
+	if !cmp.Equal(folds, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, folds)
+	}
+
+	// Shuffled (each row is in exactly one test set)
+	folds, err = KFold(ctx, df, 3, KFoldOptions{Shuffle: true, Src: rand.NewSource(7)})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	seen := map[int]int{}
+	for _, fold := range folds {
+		for _, row := range fold.Test {
+			seen[row]++
+		}
+		if len(fold.Train)+len(fold.Test) != 10 {
+			t.Errorf("wrong val: expected: %v actual: %v", 10, len(fold.Train)+len(fold.Test))
+		}
+	}
+	for row := 0; row < 10; row++ {
+		if seen[row] != 1 {
+			t.Errorf("wrong val: row %d is in %d test sets", row, seen[row])
+		}
+	}
+
+	// Stratified (rows of each group are dealt into the folds)
+	folds, err = KFold(ctx, df, 2, KFoldOptions{Stratify: "group"})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expected = []Split{
+		{Train: []int{1, 3, 5, 7, 9}, Test: []int{0, 2, 4, 6, 8}},
+		{Train: []int{0, 2, 4, 6, 8}, Test: []int{1, 3, 5, 7, 9}},
+	}
+	if !cmp.Equal(folds, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, folds)
+	}
+
+	// Time ordered (rows with the same time are placed in the same block)
+	folds, err = KFold(ctx, timeDataFrame(), 2, KFoldOptions{TimeOrdered: "time"})
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	expected = []Split{
+		{Train: []int{1, 3}, Test: []int{0, 4, 6}},
+		{Train: []int{0, 1, 3, 4, 6}, Test: []int{2, 5}},
+	}
+	if !cmp.Equal(folds, expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, folds)
+	}
+
+	// Invalid options
+	same := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sameDF := dataframe.NewDataFrame(dataframe.NewSeriesTime("time", nil, same, same, same, same))
+
+	for i, tc := range []struct {
+		df   *dataframe.DataFrame
+		k    int
+		opts KFoldOptions
+	}{
+		{df, 1, KFoldOptions{}},
+		{df, 11, KFoldOptions{}},
+		{df, 2, KFoldOptions{Stratify: "group", TimeOrdered: "group"}},
+		{timeDataFrame(), 7, KFoldOptions{TimeOrdered: "time"}},
+		{sameDF, 2, KFoldOptions{TimeOrdered: "time"}},
+	} {
+		_, err := KFold(ctx, tc.df, tc.k, tc.opts)
+		if err == nil {
+			t.Errorf("%d: wrong err: expected: %v actual: %v", i, "error", err)
+		}
+	}
+}
+
+func TestSplitDataFrames(t *testing.T) {
+
+	sf := dataframe.NewSeriesFloat64("price", nil, 1, 2, 3, 4)
+	sf.SetValueToStringFormatter(func(val interface{}) string {
+		if val == nil {
+			return "-"
+		}
+		return fmt.Sprintf("$%.2f", val.(float64))
+	})
+
+	base := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	st := dataframe.NewSeriesTime("time", nil, base, base.Add(time.Hour), base.Add(2*time.Hour), base.Add(3*time.Hour))
+	st.Layout = time.RFC3339
+
+	df := dataframe.NewDataFrame(sf, st)
+
+	// The training set is contiguous (View) and the test set is not (copied)
+	split := Split{Train: []int{1, 2}, Test: []int{0, 3}}
+
+	train, test, err := split.DataFrames(ctx, df)
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	for _, tc := range []struct {
+		df       *dataframe.DataFrame
+		expected []string
+	}{
+		{train, []string{"$2.00", "$3.00"}},
+		{test, []string{"$1.00", "$4.00"}},
+	} {
+		actual := []string{}
+		for row := 0; row < tc.df.NRows(); row++ {
+			actual = append(actual, tc.df.Series[0].ValueString(row))
+		}
+		if !cmp.Equal(actual, tc.expected) {
+			t.Errorf("wrong val: expected: %v actual: %v", tc.expected, actual)
+		}
+
+		if layout := tc.df.Series[1].(*dataframe.SeriesTime).Layout; layout != time.RFC3339 {
+			t.Errorf("wrong val: expected: %v actual: %v", time.RFC3339, layout)
+		}
+	}
+
+	if tv := test.Series[1].Value(1).(time.Time); !tv.Equal(base.Add(3 * time.Hour)) {
+		t.Errorf("wrong val: expected: %v actual: %v", base.Add(3*time.Hour), tv)
+	}
+	// The caller already holds the lock
+	df.Lock()
+	train, test, err = split.DataFrames(ctx, df, dataframe.DontLock)
+	df.Unlock()
+	if err != nil {
+		t.Fatalf("wrong err: expected: %v actual: %v", nil, err)
+	}
+
+	if train.NRows() != 2 || test.NRows() != 2 {
+		t.Errorf("wrong val: expected: %v actual: %v", 2, []int{train.NRows(), test.NRows()})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// epoch is used as the reference point for frequencies measured in days and weeks.
+// It is a Monday so that weeks begin on a Monday.
+var epoch = time.Date(1970, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+// frequency represents a parsed timeFreq. Only one of d or p is set.
+type frequency struct {
+	d *time.Duration
+	p *parsed
+}
+
+func parseFrequency(timeFreq string) (frequency, error) {
+
+	// Prevent negative sign
+	if len(timeFreq) > 0 && timeFreq[0:1] == "-" {
+		return frequency{}, fmt.Errorf("negative sign disallowed: %s", timeFreq)
+	}
+
+	d, err := time.ParseDuration(timeFreq)
+	if err == nil {
+		if d == 0 {
+			return frequency{}, fmt.Errorf("can't be zero: %s", timeFreq)
+		}
+		return frequency{d: &d}, nil
+	}
+
+	p, err := parse(timeFreq)
+	if err != nil {
+		return frequency{}, fmt.Errorf("could not parse: %s", timeFreq)
+	}
+	if p.isZero() {
+		return frequency{}, fmt.Errorf("can't be zero: %s", timeFreq)
+	}
+
+	// Only a single component is supported since there is no natural boundary for a mixture (eg. 1M1D)
+	var components int
+	for _, c := range []int{p.years, p.months, p.weeks, p.days} {
+		if c != 0 {
+			components++
+		}
+	}
+	if components > 1 {
+		return frequency{}, fmt.Errorf("only a single component is supported: %s", timeFreq)
+	}
+
+	return frequency{p: &p}, nil
+}
+
+// floorDiv performs integer division rounding towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floor rounds t down to a multiple of the frequency, based on the wall clock of t in loc.
+func (f frequency) floor(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+
+	// Wall clock represented as UTC
+	w := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	var fw time.Time
+
+	switch {
+	case f.d != nil:
+		fw = w.Truncate(*f.d)
+	case f.p.years != 0:
+		n := int64(f.p.years)
+		y := floorDiv(int64(w.Year()), n) * n
+		fw = time.Date(int(y), time.January, 1, 0, 0, 0, 0, time.UTC)
+	case f.p.months != 0:
+		n := int64(f.p.months)
+		m := floorDiv(int64(w.Year())*12+int64(w.Month())-1, n) * n
+		fw = time.Date(int(floorDiv(m, 12)), time.Month(m-floorDiv(m, 12)*12+1), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		n := int64(7*f.p.weeks + f.p.days)
+		days := floorDiv(w.Unix()-epoch.Unix(), 24*60*60)
+		fw = epoch.AddDate(0, 0, int(floorDiv(days, n)*n))
+	}
+
+	return time.Date(fw.Year(), fw.Month(), fw.Day(), fw.Hour(), fw.Minute(), fw.Second(), fw.Nanosecond(), loc)
+}
+
+// next returns the time one frequency after t.
+func (f frequency) next(t time.Time) time.Time {
+	if f.d != nil {
+		return t.Add(*f.d)
+	}
+	return t.AddDate(f.p.addDate(false))
+}
+
+// transform creates a new SeriesTime by applying fn to each non-nil value.
+func transform(ctx context.Context, ts *dataframe.SeriesTime, fn func(time.Time) time.Time, opts []dataframe.Options) (*dataframe.SeriesTime, error) {
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		ts.RLock()
+		defer ts.RUnlock()
+	}
+
+	st := dataframe.NewSeriesTime(ts.Name(dataframe.DontLock), &dataframe.SeriesInit{Capacity: len(ts.Values)})
+	st.Layout = ts.Layout
+	st.Location = ts.Location
+
+	for _, v := range ts.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if v == nil {
+			st.Append(nil, dataframe.DontLock)
+			continue
+		}
+
+		st.Append(fn(*v), dataframe.DontLock)
+	}
+
+	return st, nil
+}
+
+// Truncate returns a new SeriesTime where each value is rounded down to a multiple of timeFreq.
+// The multiples are measured in UTC (similar to time.Truncate), which means the location of each value
+// does not affect the result. The returned values retain their original location.
+//
+// timeFreq can be in the format: nY, nM, nW or nD, where n is a positive integer and
+// Y, M, W and D represent years, months, weeks and days respectively. Weeks begin on a Monday.
+// Alternatively, timeFreq can be a valid positive input to time.ParseDuration.
+//
+// See: https://golang.org/pkg/time/#Time.Truncate
+func Truncate(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	f, err := parseFrequency(timeFreq)
+	if err != nil {
+		return nil, err
+	}
+
+	return transform(ctx, ts, func(t time.Time) time.Time {
+		return f.floor(t, time.UTC).In(t.Location())
+	}, opts)
+}
+
+// Floor returns a new SeriesTime where each value is rounded down to a multiple of timeFreq.
+// Unlike Truncate, the multiples are measured using the wall clock of each value's location.
+// For example, a timeFreq of "1D" rounds down to midnight in the value's location.
+//
+// See Truncate for the format of timeFreq.
+func Floor(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	f, err := parseFrequency(timeFreq)
+	if err != nil {
+		return nil, err
+	}
+
+	return transform(ctx, ts, func(t time.Time) time.Time {
+		return f.floor(t, t.Location())
+	}, opts)
+}
+
+// Round returns a new SeriesTime where each value is rounded to the nearest multiple of timeFreq.
+// The multiples are measured using the wall clock of each value's location. Halfway values are rounded up.
+//
+// See Truncate for the format of timeFreq.
+func Round(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	f, err := parseFrequency(timeFreq)
+	if err != nil {
+		return nil, err
+	}
+
+	return transform(ctx, ts, func(t time.Time) time.Time {
+		lower := f.floor(t, t.Location())
+		upper := f.next(lower)
+		if t.Sub(lower) < upper.Sub(t) {
+			return lower
+		}
+		return upper
+	}, opts)
+}
@@ -21,6 +21,9 @@ type TimeGenerator func(startTime time.Time, reverse bool) NextTime
 // Y, M, W and D represent years, months, weeks and days respectively. Alternatively, timeFreq
 // can be a valid positive input to time.ParseDuration.
 //
+// Daily (and weekly) frequencies retain the wall clock of startTime in its location, even across
+// daylight saving time transitions. Frequencies from time.ParseDuration are absolute durations.
+//
 // Example:
 //
 //  gen, _ := utime.TimeIntervalGenerator("1W1D")
@@ -61,7 +64,10 @@ func TimeIntervalGenerator(timeFreq string) (TimeGenerator, error) {
 	}
 
 	return func(startTime time.Time, reverse bool) NextTime {
-		var prevTime *time.Time
+		var (
+			prevTime *time.Time
+			n        int
+		)
 
 		return func() time.Time {
 			var nt time.Time
@@ -70,7 +76,14 @@ func TimeIntervalGenerator(timeFreq string) (TimeGenerator, error) {
 				nt = startTime
 			} else {
 				if d == nil {
-					nt = (*prevTime).AddDate((*p).addDate(reverse))
+					if p.years == 0 && p.months == 0 {
+						// Daily frequencies are calculated from startTime so that the wall clock
+						// is retained across daylight saving time transitions.
+						_, _, days := (*p).addDate(reverse)
+						nt = startTime.AddDate(0, 0, n*days)
+					} else {
+						nt = (*prevTime).AddDate((*p).addDate(reverse))
+					}
 				} else {
 					if reverse {
 						nt = (*prevTime).Add(-*d)
@@ -80,6 +93,7 @@ func TimeIntervalGenerator(timeFreq string) (TimeGenerator, error) {
 				}
 			}
 			prevTime = &nt
+			n++
 			return nt
 		}
 	}, nil
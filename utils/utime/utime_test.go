@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
 )
 
 func TestUtime(t *testing.T) {
@@ -60,3 +62,74 @@ func TestGuessTimeFreq(t *testing.T) {
 		}
 	}
 }
+
+func TestRound(t *testing.T) {
+	ctx := context.Background()
+
+	loc := time.FixedZone("AEST", 10*60*60)
+	ts := dataframe.NewSeriesTime("Time Series", nil,
+		time.Date(2020, 2, 13, 22, 25, 28, 0, loc),
+		nil,
+	)
+
+	tests := []struct {
+		fn       func(context.Context, *dataframe.SeriesTime, string, ...dataframe.Options) (*dataframe.SeriesTime, error)
+		timeFreq string
+		expected time.Time
+	}{
+		{Truncate, "1D", time.Date(2020, 2, 13, 10, 0, 0, 0, loc)},
+		{Floor, "1D", time.Date(2020, 2, 13, 0, 0, 0, 0, loc)},
+		{Floor, "1W", time.Date(2020, 2, 10, 0, 0, 0, 0, loc)},
+		{Floor, "1M", time.Date(2020, 2, 1, 0, 0, 0, 0, loc)},
+		{Floor, "3M", time.Date(2020, 1, 1, 0, 0, 0, 0, loc)},
+		{Floor, "10Y", time.Date(2020, 1, 1, 0, 0, 0, 0, loc)},
+		{Floor, "15m", time.Date(2020, 2, 13, 22, 15, 0, 0, loc)},
+		{Round, "1h", time.Date(2020, 2, 13, 22, 0, 0, 0, loc)},
+		{Round, "1D", time.Date(2020, 2, 14, 0, 0, 0, 0, loc)},
+		{Round, "1M", time.Date(2020, 2, 1, 0, 0, 0, 0, loc)},
+	}
+
+	for i, tc := range tests {
+		rounded, err := tc.fn(ctx, ts, tc.timeFreq)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		if !rounded.Values[0].Equal(tc.expected) {
+			t.Errorf("%d: expected: %v actual: %v", i, tc.expected, rounded.Values[0])
+		}
+		if nc, _ := rounded.NilCount(); rounded.Values[1] != nil || nc != 1 {
+			t.Errorf("%d: expected: nil actual: %v", i, rounded.Values[1])
+		}
+	}
+
+	if _, err := Floor(ctx, ts, "1M1D"); err == nil {
+		t.Errorf("expected error for multiple components")
+	}
+}
+
+func TestTimeIntervalGeneratorDST(t *testing.T) {
+	loc, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	// Daylight saving time begins at 2:00 on 4 October 2020 (clocks jump to 3:00)
+	start := time.Date(2020, 10, 3, 2, 30, 0, 0, loc)
+
+	gen, _ := TimeIntervalGenerator("1D")
+	ntg := gen(start, false)
+
+	expected := []time.Time{
+		time.Date(2020, 10, 3, 2, 30, 0, 0, loc),
+		time.Date(2020, 10, 4, 3, 30, 0, 0, loc), // 2:30 does not exist
+		time.Date(2020, 10, 5, 2, 30, 0, 0, loc),
+	}
+
+	for i, e := range expected {
+		if nt := ntg(); !nt.Equal(e) {
+			t.Errorf("%d: expected: %v actual: %v", i, e, nt)
+		}
+	}
+}
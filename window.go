@@ -0,0 +1,329 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// WindowFn is a SQL-style window function.
+type WindowFn int
+
+const (
+	// RowNumber numbers the rows of each partition starting from 1.
+	RowNumber WindowFn = iota
+
+	// Rank ranks the rows of each partition starting from 1. Rows with equal OrderBy values have the same rank
+	// and leave a gap in the sequence.
+	Rank
+
+	// DenseRank ranks the rows of each partition starting from 1. Rows with equal OrderBy values have the same rank
+	// without leaving a gap in the sequence.
+	DenseRank
+
+	// Lag returns the value of the row Offset rows before the current row in the partition.
+	Lag
+
+	// Lead returns the value of the row Offset rows after the current row in the partition.
+	Lead
+
+	// FirstValue returns the value of the first row in the partition.
+	FirstValue
+
+	// LastValue returns the value of the last row in the partition.
+	//
+	// NOTE: Unlike SQL's default frame, the whole partition is used.
+	LastValue
+
+	// RunningSum returns the sum of the values from the first row of the partition up to the current row.
+	// Nil values are ignored.
+	RunningSum
+
+	// RunningAvg returns the average of the values from the first row of the partition up to the current row.
+	// Nil values are ignored.
+	RunningAvg
+)
+
+// String implements the fmt.Stringer interface.
+func (fn WindowFn) String() string {
+	switch fn {
+	case RowNumber:
+		return "row_number"
+	case Rank:
+		return "rank"
+	case DenseRank:
+		return "dense_rank"
+	case Lag:
+		return "lag"
+	case Lead:
+		return "lead"
+	case FirstValue:
+		return "first_value"
+	case LastValue:
+		return "last_value"
+	case RunningSum:
+		return "running_sum"
+	case RunningAvg:
+		return "running_avg"
+	default:
+		return fmt.Sprintf("WindowFn(%d)", int(fn))
+	}
+}
+
+// WindowFunc describes a window function computed by Window.
+type WindowFunc struct {
+	Fn WindowFn
+
+	// Series is the name of the Series that the function is applied to.
+	// It is not required for RowNumber, Rank and DenseRank.
+	Series string
+
+	// Name is the name of the new Series. When blank, the name is generated from Fn and Series (eg. "lag_price").
+	Name string
+
+	// Offset is the number of rows used by Lag and Lead. When 0, 1 is used.
+	Offset int
+}
+
+func (wf WindowFunc) name() string {
+	if wf.Name != "" {
+		return wf.Name
+	}
+	if wf.Series == "" {
+		return wf.Fn.String()
+	}
+	return wf.Fn.String() + "_" + wf.Series
+}
+
+// WindowOptions modifies the behavior of Window.
+type WindowOptions struct {
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Window computes SQL-style window functions and appends the results to df as new Series.
+// The rows are grouped by the values of the partitionBy Series and ordered within each partition
+// using orderBy. Rows that are equal according to orderBy retain their original order.
+// The order of the rows of df is not modified.
+//
+// RowNumber, Rank and DenseRank produce a SeriesInt64. RunningSum and RunningAvg produce a SeriesFloat64.
+// The other functions produce a Series of the same type as the Series they are applied to.
+//
+// Example:
+//
+//  // SELECT *, RANK() OVER (PARTITION BY dept ORDER BY salary DESC), LAG(salary) OVER (...) FROM df
+//  err := dataframe.Window(ctx, df, []string{"dept"}, []dataframe.SortKey{{Key: "salary", Desc: true}}, []dataframe.WindowFunc{
+//     {Fn: dataframe.Rank},
+//     {Fn: dataframe.Lag, Series: "salary"},
+//  })
+//
+func Window(ctx context.Context, df *DataFrame, partitionBy []string, orderBy []SortKey, funcs []WindowFunc, opts ...WindowOptions) (rErr error) {
+
+	defer func() {
+		if x := recover(); x != nil {
+			if x == context.Canceled || x == context.DeadlineExceeded {
+				rErr = x.(error)
+			} else {
+				panic(x)
+			}
+		}
+	}()
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.Lock()
+		defer df.lock.Unlock()
+	}
+
+	// Validate functions
+	names := map[string]struct{}{}
+	for _, s := range df.Series {
+		names[s.Name(dontLock)] = struct{}{}
+	}
+
+	srcs := make([]Series, 0, len(funcs))
+	for _, wf := range funcs {
+		var src Series
+		switch wf.Fn {
+		case RowNumber, Rank, DenseRank:
+		case Lag, Lead, FirstValue, LastValue, RunningSum, RunningAvg:
+			idx, err := df.NameToColumn(wf.Series, dontLock)
+			if err != nil {
+				return err
+			}
+			src = df.Series[idx]
+		default:
+			return fmt.Errorf("unknown window function: %s", wf.Fn)
+		}
+		srcs = append(srcs, src)
+
+		name := wf.name()
+		if _, exists := names[name]; exists {
+			return &SeriesError{Series: name, Err: errors.New("names of series must be unique")}
+		}
+		names[name] = struct{}{}
+	}
+
+	keys, err := df.sortKeys(orderBy)
+	if err != nil {
+		return err
+	}
+
+	// Partition rows
+	partSeries := make([]Series, 0, len(partitionBy))
+	for _, name := range partitionBy {
+		idx, err := df.NameToColumn(name, dontLock)
+		if err != nil {
+			return err
+		}
+		partSeries = append(partSeries, df.Series[idx])
+	}
+
+	var (
+		groups     = map[string]int{}
+		partitions = [][]int{}
+	)
+
+	for row := 0; row < df.n; row++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		vals := make([]interface{}, 0, len(partSeries))
+		for _, s := range partSeries {
+			vals = append(vals, s.Value(row, dontLock))
+		}
+		key := groupKey(vals)
+
+		g, exists := groups[key]
+		if !exists {
+			g = len(partitions)
+			groups[key] = g
+			partitions = append(partitions, []int{})
+		}
+		partitions[g] = append(partitions[g], row)
+	}
+
+	// Order rows within each partition
+	s := &sorter{
+		keys: keys,
+		df:   df,
+		ctx:  ctx,
+	}
+
+	for _, rows := range partitions {
+		sort.SliceStable(rows, func(i, j int) bool { return s.less(rows[i], rows[j]) })
+	}
+
+	// Compute functions
+	init := &SeriesInit{Capacity: df.n}
+	newSeriess := make([]Series, 0, len(funcs))
+
+	for i, wf := range funcs {
+		var ns Series
+
+		switch wf.Fn {
+		case RowNumber, Rank, DenseRank:
+			ns = NewSeriesInt64(wf.name(), init)
+		case RunningSum, RunningAvg:
+			ns = NewSeriesFloat64(wf.name(), init)
+		default:
+			if nser, ok := srcs[i].(NewSerieser); ok {
+				ns = nser.NewSeries(wf.name(), init)
+			} else {
+				ns = NewSeriesMixed(wf.name(), init)
+			}
+		}
+
+		out := make([]interface{}, df.n)
+		for _, rows := range partitions {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if err := windowPartition(out, srcs[i], wf, rows, s); err != nil {
+				return &SeriesError{Series: wf.name(), Err: err}
+			}
+		}
+
+		for _, val := range out {
+			ns.Append(val, dontLock)
+		}
+
+		newSeriess = append(newSeriess, ns)
+	}
+
+	df.Series = append(df.Series, newSeriess...)
+
+	return nil
+}
+
+// windowPartition computes a window function for the rows of a partition (in order) and stores
+// the results in out (indexed by row).
+func windowPartition(out []interface{}, src Series, wf WindowFunc, rows []int, s *sorter) error {
+
+	// peer reports whether the rows at positions i and j of the partition are equal according to orderBy.
+	peer := func(i, j int) bool {
+		return !s.less(rows[i], rows[j]) && !s.less(rows[j], rows[i])
+	}
+
+	offset := wf.Offset
+	if offset == 0 {
+		offset = 1
+	}
+
+	var (
+		rank  int
+		sum   float64
+		count int
+	)
+
+	for pos, row := range rows {
+		switch wf.Fn {
+		case RowNumber:
+			out[row] = int64(pos + 1)
+		case Rank:
+			if pos == 0 || !peer(pos-1, pos) {
+				rank = pos + 1
+			}
+			out[row] = int64(rank)
+		case DenseRank:
+			if pos == 0 || !peer(pos-1, pos) {
+				rank++
+			}
+			out[row] = int64(rank)
+		case Lag, Lead:
+			p := pos - offset
+			if wf.Fn == Lead {
+				p = pos + offset
+			}
+			if p >= 0 && p < len(rows) {
+				out[row] = src.Value(rows[p], dontLock)
+			}
+		case FirstValue:
+			out[row] = src.Value(rows[0], dontLock)
+		case LastValue:
+			out[row] = src.Value(rows[len(rows)-1], dontLock)
+		case RunningSum, RunningAvg:
+			if val := src.Value(row, dontLock); val != nil {
+				f, err := aggValue(val)
+				if err != nil {
+					return &RowError{Row: row, Err: err}
+				}
+				sum = sum + f
+				count++
+			}
+
+			if wf.Fn == RunningSum {
+				out[row] = sum
+			} else if count > 0 {
+				out[row] = sum / float64(count)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+//go:build go1.23
+// +build go1.23
+
+package xseries
+
+import (
+	"iter"
+	"math/cmplx"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// All returns an iterator over the rows and values of the Series.
+// It is the range-over-func equivalent of ValuesIterator.
+func (s *SeriesComplex128) All(opts ...dataframe.ValuesOptions) iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for row, v := range s.Complex128s(opts...) {
+			var out interface{} = v
+			if cmplx.IsNaN(v) {
+				out = nil
+			}
+			if !yield(row, out) {
+				return
+			}
+		}
+	}
+}
+
+// Complex128s returns an iterator over the rows and values of the Series. Nil values are returned as NaN.
+func (s *SeriesComplex128) Complex128s(opts ...dataframe.ValuesOptions) iter.Seq2[int, complex128] {
+
+	var (
+		initial      int
+		step         int = 1
+		dontReadLock bool
+	)
+
+	if len(opts) > 0 {
+		dontReadLock = opts[0].DontReadLock
+		initial = opts[0].InitialRow
+		if opts[0].Step != 0 {
+			step = opts[0].Step
+		}
+	}
+
+	return func(yield func(int, complex128) bool) {
+		row := initial
+		first := true
+
+		for {
+			if !dontReadLock {
+				s.lock.RLock()
+			}
+
+			if first && row < 0 {
+				row = len(s.Values) + row
+			}
+			first = false
+
+			if row > len(s.Values)-1 || row < 0 {
+				// Don't iterate further
+				if !dontReadLock {
+					s.lock.RUnlock()
+				}
+				return
+			}
+
+			v := s.Values[row]
+
+			if !dontReadLock {
+				s.lock.RUnlock()
+			}
+
+			if !yield(row, v) {
+				return
+			}
+			row = row + step
+		}
+	}
+}
@@ -6,6 +6,8 @@ package xseries
 import (
 	"bytes"
 	"context"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"golang.org/x/exp/rand"
 	"math"
@@ -28,6 +30,7 @@ type SeriesComplex128 struct {
 	// See: https://godoc.org/gonum.org/v1/gonum
 	Values   []complex128
 	nilCount int
+	shared   bool // true when the underlying storage may be shared with a View
 }
 
 // NewSeriesComplex128 creates a new series with the underlying type as complex128.
@@ -180,6 +183,8 @@ func (s *SeriesComplex128) Prepend(val interface{}, opts ...dataframe.Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
 
 	if cap(s.Values) > len(s.Values) {
@@ -224,6 +229,8 @@ func (s *SeriesComplex128) Insert(row int, val interface{}, opts ...dataframe.Op
 }
 
 func (s *SeriesComplex128) insert(row int, val interface{}) {
+	s.own()
+
 	switch V := val.(type) {
 	case []complex128:
 		// count how many NaN
@@ -262,6 +269,8 @@ func (s *SeriesComplex128) Remove(row int, opts ...dataframe.Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	if cmplx.IsNaN(s.Values[row]) {
 		s.nilCount--
 	}
@@ -277,6 +286,7 @@ func (s *SeriesComplex128) Reset(opts ...dataframe.Options) {
 	}
 
 	s.Values = []complex128{}
+	s.shared = false
 	s.nilCount = 0
 }
 
@@ -289,6 +299,8 @@ func (s *SeriesComplex128) Update(row int, val interface{}, opts ...dataframe.Op
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	newVal := s.valToPointer(val)
 
 	if cmplx.IsNaN(s.Values[row]) && !cmplx.IsNaN(newVal) {
@@ -442,6 +454,8 @@ func (s *SeriesComplex128) Swap(row1, row2 int, opts ...dataframe.Options) {
 		defer s.lock.Unlock()
 	}
 
+	s.own()
+
 	s.Values[row1], s.Values[row2] = s.Values[row2], s.Values[row1]
 }
 
@@ -506,6 +520,8 @@ func (s *SeriesComplex128) Sort(ctx context.Context, opts ...dataframe.SortOptio
 		defer s.Unlock()
 	}
 
+	s.own()
+
 	sortFunc := func(i, j int) (ret bool) {
 		if err := ctx.Err(); err != nil {
 			panic(err)
@@ -517,6 +533,20 @@ func (s *SeriesComplex128) Sort(ctx context.Context, opts ...dataframe.SortOptio
 			}
 		}()
 
+		if opts[0].Nils != dataframe.NilsDefault {
+			iNil, jNil := cmplx.IsNaN(s.Values[i]), cmplx.IsNaN(s.Values[j])
+			if iNil || jNil {
+				var less bool
+				if opts[0].Nils == dataframe.NilsLast {
+					less = jNil && !iNil
+				} else {
+					less = iNil && !jNil
+				}
+				// Pre-invert since ret is inverted above for Desc
+				return less != opts[0].Desc
+			}
+		}
+
 		if cmplx.IsNaN(s.Values[i]) {
 			if cmplx.IsNaN(s.Values[j]) {
 				// both are nil
@@ -556,6 +586,16 @@ func (s *SeriesComplex128) Unlock() {
 	s.lock.Unlock()
 }
 
+// RLock will lock the Series for reading. Other readers are not blocked.
+func (s *SeriesComplex128) RLock() {
+	s.lock.RLock()
+}
+
+// RUnlock will unlock the Series that was previously locked for reading.
+func (s *SeriesComplex128) RUnlock() {
+	s.lock.RUnlock()
+}
+
 // Copy will create a new copy of the series.
 // It is recommended that you lock the Series before attempting
 // to Copy.
@@ -591,6 +631,60 @@ func (s *SeriesComplex128) Copy(r ...dataframe.Range) dataframe.Series {
 	}
 }
 
+// View returns a Series that shares the underlying storage of s for the rows in r (zero-copy).
+// The storage is copied (copy-on-write) when either Series is subsequently modified using
+// Update, Insert, Remove etc.
+//
+// NOTE: Modifying Values directly does not trigger a copy.
+func (s *SeriesComplex128) View(r dataframe.Range) dataframe.Series {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.Values) == 0 {
+		return s.Copy()
+	}
+
+	start, end, err := r.Limits(len(s.Values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Limit capacity so appending to the view never overwrites s
+	vals := s.Values[start : end+1 : end+1]
+
+	nilCount := s.nilCount
+	if len(vals) != len(s.Values) {
+		nilCount = 0
+		for _, v := range vals {
+			if cmplx.IsNaN(v) {
+				nilCount++
+			}
+		}
+	}
+
+	s.shared = true
+
+	return &SeriesComplex128{
+		valFormatter: s.valFormatter,
+		name:         s.name,
+		Values:       vals,
+		nilCount:     nilCount,
+		shared:       true,
+	}
+}
+
+// own copies the underlying storage if it may be shared with a View.
+func (s *SeriesComplex128) own() {
+	if !s.shared {
+		return
+	}
+
+	vals := make([]complex128, len(s.Values), cap(s.Values))
+	copy(vals, s.Values)
+	s.Values = vals
+	s.shared = false
+}
+
 // Table will produce the Series in a table.
 func (s *SeriesComplex128) Table(opts ...dataframe.TableOptions) string {
 
@@ -891,6 +985,8 @@ func (s *SeriesComplex128) FillRand(src rand.Source, probNil float64, rander dat
 
 	rng := rand.New(src)
 
+	s.own()
+
 	capacity := cap(s.Values)
 	length := len(s.Values)
 	s.nilCount = 0
@@ -944,6 +1040,11 @@ func (s *SeriesComplex128) IsEqual(ctx context.Context, s2 dataframe.Series, opt
 		}
 	}
 
+	// Approximate equality
+	if len(opts) != 0 && opts[0].Approximate() {
+		return dataframe.IsEqualApprox(ctx, s, s2, opts[0])
+	}
+
 	// Check values
 	for i, v := range s.Values {
 		if err := ctx.Err(); err != nil {
@@ -961,3 +1062,126 @@ func (s *SeriesComplex128) IsEqual(ctx context.Context, s2 dataframe.Series, opt
 
 	return true, nil
 }
+
+func init() {
+	dataframe.RegisterSeries("xseries.SeriesComplex128", &SeriesComplex128{})
+}
+
+type seriesComplex128Binary struct {
+	Name      string
+	Values    []complex128
+	Formatter string
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The Series can also be encoded using the gob package.
+func (s *SeriesComplex128) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(seriesComplex128Binary{
+		Name:      s.name,
+		Values:    s.Values,
+		Formatter: dataframe.ValueFormatterName(s.valFormatter),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesComplex128) UnmarshalBinary(data []byte) error {
+	var in seriesComplex128Binary
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&in); err != nil {
+		return err
+	}
+
+	if in.Values == nil {
+		in.Values = []complex128{}
+	}
+
+	var nilCount int
+	for _, v := range in.Values {
+		if cmplx.IsNaN(v) {
+			nilCount++
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.Values = in.Values
+	s.nilCount = nilCount
+	s.valFormatter = dataframe.LookupValueFormatter(in.Formatter)
+	s.shared = false
+
+	return nil
+}
+
+type seriesComplex128JSON struct {
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	Formatter string        `json:"formatter,omitempty"`
+	Values    []*[2]float64 `json:"values"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. Each value is encoded as an array
+// containing the real and imaginary parts. Nil values are encoded as null.
+func (s *SeriesComplex128) MarshalJSON() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	out := seriesComplex128JSON{
+		Name:      s.name,
+		Type:      "complex128",
+		Formatter: dataframe.ValueFormatterName(s.valFormatter),
+		Values:    make([]*[2]float64, 0, len(s.Values)),
+	}
+
+	for _, v := range s.Values {
+		if cmplx.IsNaN(v) {
+			out.Values = append(out.Values, nil)
+		} else {
+			out.Values = append(out.Values, &[2]float64{real(v), imag(v)})
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SeriesComplex128) UnmarshalJSON(data []byte) error {
+	var in seriesComplex128JSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	if in.Type != "complex128" {
+		return fmt.Errorf("wrong type: expected: %s got: %s", "complex128", in.Type)
+	}
+
+	var nilCount int
+	vals := make([]complex128, 0, len(in.Values))
+	for _, v := range in.Values {
+		if v == nil {
+			vals = append(vals, cmplx.NaN())
+			nilCount++
+		} else {
+			vals = append(vals, complex(v[0], v[1]))
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = in.Name
+	s.Values = vals
+	s.nilCount = nilCount
+	s.valFormatter = dataframe.LookupValueFormatter(in.Formatter)
+	s.shared = false
+
+	return nil
+}